@@ -9,12 +9,16 @@ import (
 )
 
 func main() {
-	cfg := config.NewConfig()
-
 	logger.InitGlobalLogger()
-	logger.Info(context.Background(), "Starting application", LogAppName(cfg), LogEnv(cfg))
 
-	app := appPkg.NewApp()
+	watcher, err := config.NewWatcher()
+	if err != nil {
+		logger.Fatal(context.Background(), "Invalid configuration", logger.ErrorField(logger.FieldError, err))
+	}
+	cfg := watcher.Current()
+	logger.Info(context.Background(), "Starting application", LogAppName(cfg), LogEnv(cfg), LogProfile(cfg))
+
+	app := appPkg.NewApp(watcher)
 	app.Start()
 }
 
@@ -22,6 +26,10 @@ func LogEnv(cfg *config.Config) logger.Field {
 	return logger.String("environment", cfg.GetEnv())
 }
 
+func LogProfile(cfg *config.Config) logger.Field {
+	return logger.String("profile", string(cfg.GetProfile()))
+}
+
 func LogAppName(cfg *config.Config) logger.Field {
 	return logger.String("app_name", cfg.GetAppName())
 }
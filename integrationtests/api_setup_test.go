@@ -6,16 +6,36 @@ import (
 	"testing"
 	"time"
 
+	"go-service-template/internal/infrastructure/config"
 	appPkg "go-service-template/server/app"
 
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
 	"gopkg.in/h2non/baloo.v3"
 )
 
 const (
-	testHost = "127.0.0.1"
-	testPort = "18080"
+	testHost      = "127.0.0.1"
+	testPort      = "18080"
+	testJWTSecret = "integration-tests-secret-0123456789"
 )
 
+// adminToken signs a token with the admin role, which every API route accepts.
+func adminToken() string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte(testJWTSecret)}, nil)
+	if err != nil {
+		panic(err)
+	}
+	token, err := jwt.Signed(signer).
+		Claims(jwt.Claims{Subject: "integration-tests", Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour))}).
+		Claims(map[string]any{"roles": []string{"admin"}}).
+		Serialize()
+	if err != nil {
+		panic(err)
+	}
+	return token
+}
+
 // waitForServer polls the health endpoint until the server is ready or times out.
 func waitForServer(url string, timeout time.Duration) bool {
 	deadline := time.Now().Add(timeout)
@@ -37,8 +57,13 @@ var TestClient *baloo.Client
 func TestMain(m *testing.M) {
 	_ = os.Setenv("HOST", testHost)
 	_ = os.Setenv("PORT", testPort)
+	_ = os.Setenv("JWT_SECRET", testJWTSecret)
 
-	go appPkg.NewApp().Start()
+	watcher, err := config.NewWatcher()
+	if err != nil {
+		os.Exit(1)
+	}
+	go appPkg.NewApp(watcher).Start()
 
 	if ok := waitForServer("http://"+testHost+":"+testPort+"/health", 5*time.Second); !ok {
 		os.Exit(1)
@@ -48,7 +73,8 @@ func TestMain(m *testing.M) {
 		SetHeader("Client-Version", "test").
 		SetHeader("Client-Platform", "android").
 		SetHeader("Client-Language", "en-IN").
-		SetHeader("Api-Version", "test")
+		SetHeader("Api-Version", "test").
+		SetHeader("Authorization", "Bearer "+adminToken())
 
 	code := m.Run()
 	os.Exit(code)
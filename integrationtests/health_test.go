@@ -12,3 +12,12 @@ func Test_HealthCheck(t *testing.T) {
 		JSON(LoadJSON("test_data/health/health_response.json")).
 		Done()
 }
+
+func Test_LivenessCheck(t *testing.T) {
+	_ = TestClient.
+		Get("/health/live").
+		Expect(t).
+		Status(200).
+		JSON(LoadJSON("test_data/health/health_response.json")).
+		Done()
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"go-service-template/internal/api/dto"
+	"go-service-template/internal/infrastructure/auth"
+	"go-service-template/internal/infrastructure/context"
+	"go-service-template/internal/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KeyHandler lets operators issue, rotate and revoke the API keys of internal callers.
+type KeyHandler struct {
+	keys auth.APIKeyManager
+}
+
+func NewKeyHandler(keys auth.APIKeyManager) *KeyHandler {
+	return &KeyHandler{
+		keys: keys,
+	}
+}
+
+// List returns every key with its scopes, expiry and last use, never its value.
+func (h *KeyHandler) List(ctx *context.GinContext) {
+	keys, err := h.keys.List(logger.GetLogContext(ctx.Context))
+	if err != nil {
+		h.sendErrorResponse(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"api_keys": keys})
+}
+
+// Issue creates a key and returns its value, which is not stored and cannot be shown again.
+func (h *KeyHandler) Issue(ctx *context.GinContext) {
+	logCtx := logger.GetLogContext(ctx.Context)
+
+	var req dto.IssueAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+	spec, err := apiKeySpec(&req, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid duration: " + err.Error()})
+		return
+	}
+
+	value, key, err := h.keys.Issue(logCtx, spec)
+	if err != nil {
+		h.sendErrorResponse(ctx, err)
+		return
+	}
+	logger.Warn(logCtx, "API key issued",
+		logger.String("api_key_id", key.ID),
+		logger.String(logger.FieldTenant, key.Tenant),
+		logger.Bool("rotate", spec.Rotate),
+	)
+	ctx.JSON(http.StatusCreated, gin.H{"key": value, "api_key": key})
+}
+
+// Revoke deletes every key of the ID in the path.
+func (h *KeyHandler) Revoke(ctx *context.GinContext) {
+	logCtx := logger.GetLogContext(ctx.Context)
+	id := ctx.Param("id")
+	revoked, err := h.keys.Revoke(logCtx, id)
+	if err != nil {
+		h.sendErrorResponse(ctx, err)
+		return
+	}
+	logger.Warn(logCtx, "API keys revoked", logger.String("api_key_id", id), logger.Int("revoked", revoked))
+	ctx.JSON(http.StatusOK, gin.H{"revoked": revoked})
+}
+
+func apiKeySpec(req *dto.IssueAPIKeyRequest, now time.Time) (*auth.APIKeySpec, error) {
+	spec := &auth.APIKeySpec{ID: req.ID, Scopes: req.Scopes, Tenant: req.Tenant, Rotate: req.Rotate}
+	if req.ExpiresIn != "" {
+		ttl, err := time.ParseDuration(req.ExpiresIn)
+		if err != nil {
+			return nil, err
+		}
+		spec.ExpiresAt = now.Add(ttl)
+	}
+	if req.Overlap != "" {
+		overlap, err := time.ParseDuration(req.Overlap)
+		if err != nil {
+			return nil, err
+		}
+		spec.Overlap = overlap
+	}
+	return spec, nil
+}
+
+func (h *KeyHandler) sendErrorResponse(ctx *context.GinContext, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, auth.ErrInvalidAPIKeySpec):
+		status = http.StatusBadRequest
+	case errors.Is(err, auth.ErrAPIKeysReadOnly):
+		status = http.StatusConflict
+	case errors.Is(err, auth.ErrUnavailable):
+		status = http.StatusServiceUnavailable
+	}
+	ctx.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-service-template/internal/infrastructure/auth"
+	"go-service-template/internal/infrastructure/auth/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyHandler_Issue_ReturnsKeyOnce(t *testing.T) {
+	keys := mocks.NewAPIKeyManager(t)
+	keys.EXPECT().Issue(mock.Anything, mock.MatchedBy(func(spec *auth.APIKeySpec) bool {
+		return spec.ID == "billing" && spec.Tenant == "acme" && spec.Rotate && spec.Overlap == time.Hour && !spec.ExpiresAt.IsZero()
+	})).Return("secret-value", &auth.APIKey{ID: "billing", Scopes: []string{auth.ScopeLimitCheck}}, nil)
+	w, ctx := setupLogLevelContext(t, http.MethodPost, "/admin/api-keys",
+		`{"id":"billing","scopes":["limit:check"],"tenant":"acme","expires_in":"720h","rotate":true,"overlap":"1h"}`)
+
+	NewKeyHandler(keys).Issue(ctx)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp struct {
+		Key    string      `json:"key"`
+		APIKey auth.APIKey `json:"api_key"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "secret-value", resp.Key)
+	assert.Equal(t, "billing", resp.APIKey.ID)
+}
+
+func TestKeyHandler_Issue_InvalidDuration(t *testing.T) {
+	keys := mocks.NewAPIKeyManager(t)
+	w, ctx := setupLogLevelContext(t, http.MethodPost, "/admin/api-keys",
+		`{"id":"billing","scopes":["limit:check"],"tenant":"acme","overlap":"soon"}`)
+
+	NewKeyHandler(keys).Issue(ctx)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestKeyHandler_Issue_ReadOnly(t *testing.T) {
+	keys := mocks.NewAPIKeyManager(t)
+	keys.EXPECT().Issue(mock.Anything, mock.Anything).Return("", nil, auth.ErrAPIKeysReadOnly)
+	w, ctx := setupLogLevelContext(t, http.MethodPost, "/admin/api-keys", `{"id":"billing","scopes":["limit:check"],"tenant":"acme"}`)
+
+	NewKeyHandler(keys).Issue(ctx)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestKeyHandler_List_OmitsValues(t *testing.T) {
+	keys := mocks.NewAPIKeyManager(t)
+	keys.EXPECT().List(mock.Anything).Return([]auth.APIKey{{ID: "billing", Hash: "abc", Scopes: []string{auth.ScopeLimitCheck}, Source: auth.APIKeySourceRedis}}, nil)
+	w, ctx := setupLogLevelContext(t, http.MethodGet, "/admin/api-keys", "")
+
+	NewKeyHandler(keys).List(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"api_keys":[{"id":"billing","hash":"abc","scopes":["limit:check"],"source":"redis"}]}`, w.Body.String())
+}
+
+func TestKeyHandler_Revoke(t *testing.T) {
+	keys := mocks.NewAPIKeyManager(t)
+	keys.EXPECT().Revoke(mock.Anything, "").Return(2, nil)
+	w, ctx := setupLogLevelContext(t, http.MethodDelete, "/admin/api-keys/billing", "")
+
+	NewKeyHandler(keys).Revoke(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"revoked":2}`, w.Body.String())
+}
@@ -0,0 +1,13 @@
+package dto
+
+// IssueAPIKeyRequest represents the request for issuing an API key, bound to Tenant.
+// ExpiresIn and Overlap are durations; without ExpiresIn the key never expires. Rotate
+// retires the other keys of ID once Overlap has elapsed.
+type IssueAPIKeyRequest struct {
+	ID        string   `json:"id" binding:"required"`
+	Scopes    []string `json:"scopes" binding:"required,min=1"`
+	Tenant    string   `json:"tenant" binding:"required"`
+	ExpiresIn string   `json:"expires_in"`
+	Rotate    bool     `json:"rotate"`
+	Overlap   string   `json:"overlap"`
+}
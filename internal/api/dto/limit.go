@@ -2,7 +2,8 @@ package dto
 
 // CheckLimitRequest represents the request for checking limit.
 type CheckLimitRequest struct {
-	UserID int `json:"userID" binding:"required"`
+	UserID int    `json:"userID" binding:"required"`
+	Policy string `json:"policy,omitempty"`
 }
 
 // CheckLimitResponse represents the response for limit.
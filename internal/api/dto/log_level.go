@@ -0,0 +1,9 @@
+package dto
+
+// LogLevelRequest represents the request for temporarily changing the log level.
+// An empty Route changes the global level; Duration defaults to ten minutes.
+type LogLevelRequest struct {
+	Level    string `json:"level" binding:"required,oneof=debug info warn error"`
+	Route    string `json:"route"`
+	Duration string `json:"duration"`
+}
@@ -4,23 +4,83 @@ import (
 	"net/http"
 
 	"go-service-template/internal/infrastructure/context"
+	"go-service-template/internal/infrastructure/health"
 	"go-service-template/internal/infrastructure/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
-type HealthHandler struct{}
+type IHealthHandler interface {
+	Live(ctx *context.GinContext)
+	Ready(ctx *context.GinContext)
+	Startup(ctx *context.GinContext)
+}
+
+type HealthHandler struct {
+	checks *health.Registry
+}
 
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+func NewHealthHandler(checks *health.Registry) *HealthHandler {
+	return &HealthHandler{
+		checks: checks,
+	}
 }
 
-func (h *HealthHandler) Check(ctx *context.GinContext) {
+// Live reports that the process is serving requests. It checks no dependency, so an
+// outage elsewhere does not get the service restarted.
+func (h *HealthHandler) Live(ctx *context.GinContext) {
 	logCtx := logger.GetLogContext(ctx.Context)
 	logger.Info(logCtx, "Health check requested", logger.Int(logger.FieldStatusCode, http.StatusOK))
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"status":  "ok",
-		"service": "go-service-template",
+		"status":  health.StatusOK,
+		"service": serviceName,
+	})
+}
+
+// Ready reports every dependency check, answering 503 when a critical one fails.
+func (h *HealthHandler) Ready(ctx *context.GinContext) {
+	logCtx := logger.GetLogContext(ctx.Context)
+	report := h.checks.Report(logCtx)
+
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	if report.Status == health.StatusOK {
+		logger.Info(logCtx, "Readiness check requested", logger.Int(logger.FieldStatusCode, status))
+	} else {
+		logger.Warn(logCtx, "Readiness check failing",
+			logger.Int(logger.FieldStatusCode, status),
+			logger.String("health_status", report.Status),
+		)
+	}
+
+	ctx.JSON(status, gin.H{
+		"status":  report.Status,
+		"service": serviceName,
+		"checks":  report.Checks,
+	})
+}
+
+// Startup reports the progress of the startup phase, answering 503 until it is over.
+// Once started, dependencies that were unavailable keep reconnecting in the background
+// and their outage is reported by the readiness probe.
+func (h *HealthHandler) Startup(ctx *context.GinContext) {
+	logCtx := logger.GetLogContext(ctx.Context)
+	report := h.checks.StartupReport()
+
+	status := http.StatusOK
+	if !report.Started() {
+		status = http.StatusServiceUnavailable
+	}
+	logger.Info(logCtx, "Startup check requested", logger.Int(logger.FieldStatusCode, status))
+
+	ctx.JSON(status, gin.H{
+		"status":       report.Status,
+		"service":      serviceName,
+		"dependencies": report.Dependencies,
 	})
 }
+
+const serviceName = "go-service-template"
@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	ginContext "go-service-template/internal/infrastructure/context"
+	"go-service-template/internal/infrastructure/health"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -15,22 +17,22 @@ import (
 )
 
 func TestNewHealthHandler_ValidInput_ReturnsHealthHandler(t *testing.T) {
-	handler := NewHealthHandler()
+	handler := NewHealthHandler(health.NewRegistry(health.Config{}))
 
 	assert.NotNil(t, handler)
 	assert.IsType(t, &HealthHandler{}, handler)
 }
 
-func TestHealthHandler_Check_ValidContext_ReturnsOKStatus(t *testing.T) {
-	handler := NewHealthHandler()
+func TestHealthHandler_Live_ValidContext_ReturnsOKStatus(t *testing.T) {
+	handler := NewHealthHandler(health.NewRegistry(health.Config{}))
 	w, ginCtx := setupHealthTestContext(t)
 	
-	handler.Check(ginCtx)
+	handler.Live(ginCtx)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
-func TestHealthHandler_Check_ResponseFields_ReturnsCorrectValues(t *testing.T) {
+func TestHealthHandler_Live_ResponseFields_ReturnsCorrectValues(t *testing.T) {
 	tests := []struct {
 		name           string
 		field          string
@@ -65,10 +67,10 @@ func TestHealthHandler_Check_ResponseFields_ReturnsCorrectValues(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := NewHealthHandler()
+			handler := NewHealthHandler(health.NewRegistry(health.Config{}))
 			w, ginCtx := tt.setupContext(t)
 			
-			handler.Check(ginCtx)
+			handler.Live(ginCtx)
 
 			var response map[string]interface{}
 			err := json.Unmarshal(w.Body.Bytes(), &response)
@@ -79,7 +81,7 @@ func TestHealthHandler_Check_ResponseFields_ReturnsCorrectValues(t *testing.T) {
 	}
 }
 
-func TestHealthHandler_Check_ResponseHeaders_ReturnsCorrectContentType(t *testing.T) {
+func TestHealthHandler_Live_ResponseHeaders_ReturnsCorrectContentType(t *testing.T) {
 	tests := []struct {
 		name         string
 		setupContext func(t *testing.T) (*httptest.ResponseRecorder, *ginContext.GinContext)
@@ -96,17 +98,17 @@ func TestHealthHandler_Check_ResponseHeaders_ReturnsCorrectContentType(t *testin
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := NewHealthHandler()
+			handler := NewHealthHandler(health.NewRegistry(health.Config{}))
 			w, ginCtx := tt.setupContext(t)
 			
-			handler.Check(ginCtx)
+			handler.Live(ginCtx)
 
 			assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
 		})
 	}
 }
 
-func TestHealthHandler_Check_ResponseFormat_ReturnsValidJSON(t *testing.T) {
+func TestHealthHandler_Live_ResponseFormat_ReturnsValidJSON(t *testing.T) {
 	tests := []struct {
 		name         string
 		setupContext func(t *testing.T) (*httptest.ResponseRecorder, *ginContext.GinContext)
@@ -123,10 +125,10 @@ func TestHealthHandler_Check_ResponseFormat_ReturnsValidJSON(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := NewHealthHandler()
+			handler := NewHealthHandler(health.NewRegistry(health.Config{}))
 			w, ginCtx := tt.setupContext(t)
 			
-			handler.Check(ginCtx)
+			handler.Live(ginCtx)
 
 			var response map[string]interface{}
 			err := json.Unmarshal(w.Body.Bytes(), &response)
@@ -137,11 +139,11 @@ func TestHealthHandler_Check_ResponseFormat_ReturnsValidJSON(t *testing.T) {
 	}
 }
 
-func TestHealthHandler_Check_CompleteResponse_ReturnsExpectedStructure(t *testing.T) {
-	handler := NewHealthHandler()
+func TestHealthHandler_Live_CompleteResponse_ReturnsExpectedStructure(t *testing.T) {
+	handler := NewHealthHandler(health.NewRegistry(health.Config{}))
 	w, ginCtx := setupHealthTestContext(t)
 	
-	handler.Check(ginCtx)
+	handler.Live(ginCtx)
 
 	expectedResponse := map[string]interface{}{
 		"status":  "ok",
@@ -180,4 +182,58 @@ func setupHealthTestContextWithRequest(t *testing.T) (*httptest.ResponseRecorder
 	require.NoError(t, err)
 	
 	return w, ginCtx
-}
\ No newline at end of file
+}
+func TestHealthHandler_Ready_ReportsChecks(t *testing.T) {
+	tests := []struct {
+		name           string
+		critical       bool
+		probeErr       error
+		expectedCode   int
+		expectedStatus string
+	}{
+		{name: "AllPassing", expectedCode: http.StatusOK, expectedStatus: health.StatusOK},
+		{name: "NonCriticalFailing", probeErr: assert.AnError, expectedCode: http.StatusOK, expectedStatus: health.StatusDegraded},
+		{name: "CriticalFailing", critical: true, probeErr: assert.AnError, expectedCode: http.StatusServiceUnavailable, expectedStatus: health.StatusDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks := health.NewRegistry(health.Config{})
+			checks.Register(health.Check{Name: "redis", Critical: tt.critical, Probe: func(context.Context) error { return tt.probeErr }})
+			handler := NewHealthHandler(checks)
+			w, ginCtx := setupHealthTestContextWithRequest(t)
+
+			handler.Ready(ginCtx)
+
+			var response struct {
+				Status string                   `json:"status"`
+				Checks map[string]health.Result `json:"checks"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, tt.expectedStatus, response.Status)
+			assert.Contains(t, response.Checks, "redis")
+		})
+	}
+}
+
+func TestHealthHandler_Startup_ReportsPhase(t *testing.T) {
+	checks := health.NewRegistry(health.Config{})
+	checks.Register(health.Check{Name: "redis", Critical: true, Probe: func(context.Context) error { return nil }})
+	handler := NewHealthHandler(checks)
+
+	starting, ginCtx := setupHealthTestContextWithRequest(t)
+	handler.Startup(ginCtx)
+	assert.Equal(t, http.StatusServiceUnavailable, starting.Code)
+
+	checks.Start(context.Background(), health.StartupConfig{Timeout: time.Minute, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	require.Eventually(t, func() bool {
+		report := checks.StartupReport()
+		return report.Started()
+	}, time.Second, time.Millisecond)
+	started, ginCtx := setupHealthTestContextWithRequest(t)
+	handler.Startup(ginCtx)
+
+	assert.Equal(t, http.StatusOK, started.Code)
+	assert.Contains(t, started.Body.String(), `"redis":{"status":"connected","attempts":1`)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	stdctx "context"
 	"net/http"
 
 	"go-service-template/internal/api/dto"
@@ -27,21 +28,21 @@ func NewLimiterHandler(limiter limitPkg.ILimitUseCase) ILimiterHandler {
 }
 
 func (api *limiterHandler) CheckLimit(ctx *context.GinContext) {
-	api.handleLimit(ctx, "Checking limit", func(req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error) {
-		return api.limit.CheckLimit(req)
+	api.handleLimit(ctx, "Checking limit", func(logCtx stdctx.Context, req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error) {
+		return api.limit.CheckLimit(logCtx, req)
 	}, "Limit checked successfully", "Failed to fetch limit")
 }
 
 func (api *limiterHandler) ResetLimit(ctx *context.GinContext) {
-	api.handleLimit(ctx, "Resetting limit", func(req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error) {
-		return api.limit.ResetLimit(req)
+	api.handleLimit(ctx, "Resetting limit", func(logCtx stdctx.Context, req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error) {
+		return api.limit.ResetLimit(logCtx, req)
 	}, "Limit reset successfully", "Failed to reset limit")
 }
 
 func (api *limiterHandler) handleLimit(
 	ctx *context.GinContext,
 	startMsg string,
-	usecaseFn func(req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error),
+	usecaseFn func(ctx stdctx.Context, req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error),
 	successMsg string,
 	errorPrefix string,
 ) {
@@ -55,15 +56,15 @@ func (api *limiterHandler) handleLimit(
 		return
 	}
 
-	response, err := usecaseFn(&req)
+	logCtx = logger.WithFields(logCtx, logger.Int(logger.FieldUserID, req.UserID))
+	response, err := usecaseFn(logCtx, &req)
 	if err != nil {
-		logger.Error(logCtx, errorPrefix, logger.ErrorField(logger.FieldError, err), logger.Int(logger.FieldUserID, req.UserID))
+		logger.Error(logCtx, errorPrefix, logger.ErrorField(logger.FieldError, err))
 		api.sendErrorResponse(ctx, http.StatusInternalServerError, errorPrefix+": ", err)
 		return
 	}
 
 	logger.Info(logCtx, successMsg,
-		logger.Int(logger.FieldUserID, req.UserID),
 		logger.Int(logger.FieldStatusCode, http.StatusOK),
 		logger.Int("limit_available", response.LimitAvailable),
 	)
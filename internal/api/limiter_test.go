@@ -34,7 +34,7 @@ func TestLimiterHandler_CheckLimit_ValidRequest_ReturnsSuccessResponse(t *testin
 		UserID:         123,
 		LimitAvailable: 100,
 	}
-	mockUseCase.On("CheckLimit", mock.AnythingOfType("*dto.CheckLimitRequest")).Return(expectedResponse, nil)
+	mockUseCase.On("CheckLimit", mock.Anything, mock.AnythingOfType("*dto.CheckLimitRequest")).Return(expectedResponse, nil)
 	w, ginCtx := setupLimiterTestContextWithJSON(t, dto.CheckLimitRequest{UserID: 123})
 
 	handler.CheckLimit(ginCtx)
@@ -124,7 +124,7 @@ func TestLimiterHandler_CheckLimit_UseCaseError_ReturnsInternalServerError(t *te
 			mockUseCase := &mocks.ILimitUseCase{}
 			handler := NewLimiterHandler(mockUseCase)
 
-			mockUseCase.On("CheckLimit", mock.AnythingOfType("*dto.CheckLimitRequest")).Return(dto.CheckLimitResponse{}, tt.useCaseError)
+			mockUseCase.On("CheckLimit", mock.Anything, mock.AnythingOfType("*dto.CheckLimitRequest")).Return(dto.CheckLimitResponse{}, tt.useCaseError)
 
 			w, ginCtx := setupLimiterTestContextWithJSON(t, dto.CheckLimitRequest{UserID: 123})
 
@@ -189,7 +189,7 @@ func TestLimiterHandler_CheckLimit_ValidUserIDs_ReturnsSuccessResponse(t *testin
 			mockUseCase := &mocks.ILimitUseCase{}
 			handler := NewLimiterHandler(mockUseCase)
 
-			mockUseCase.On("CheckLimit", mock.AnythingOfType("*dto.CheckLimitRequest")).Return(tt.expectedResponse, nil)
+			mockUseCase.On("CheckLimit", mock.Anything, mock.AnythingOfType("*dto.CheckLimitRequest")).Return(tt.expectedResponse, nil)
 
 			w, ginCtx := setupLimiterTestContextWithJSON(t, dto.CheckLimitRequest{UserID: tt.userID})
 
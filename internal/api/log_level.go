@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"go-service-template/internal/api/dto"
+	"go-service-template/internal/infrastructure/context"
+	"go-service-template/internal/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LogLevelHandler lets on-call engineers change log levels at runtime.
+type LogLevelHandler struct {
+	levels logger.LevelController
+}
+
+func NewLogLevelHandler(levels logger.LevelController) *LogLevelHandler {
+	return &LogLevelHandler{
+		levels: levels,
+	}
+}
+
+// Get returns the configured level and every active override.
+func (h *LogLevelHandler) Get(ctx *context.GinContext) {
+	ctx.JSON(http.StatusOK, h.levels.Levels())
+}
+
+// Put overrides the level globally or for one route until the duration elapses.
+func (h *LogLevelHandler) Put(ctx *context.GinContext) {
+	logCtx := logger.GetLogContext(ctx.Context)
+
+	var req dto.LogLevelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.sendErrorResponse(ctx, "Invalid request body: ", err)
+		return
+	}
+
+	var ttl time.Duration
+	if req.Duration != "" {
+		var err error
+		if ttl, err = time.ParseDuration(req.Duration); err != nil {
+			h.sendErrorResponse(ctx, "Invalid duration: ", err)
+			return
+		}
+	}
+
+	override, err := h.levels.Override(req.Route, req.Level, ttl)
+	if err != nil {
+		h.sendErrorResponse(ctx, "Invalid override: ", err)
+		return
+	}
+
+	logger.Warn(logCtx, "Log level overridden",
+		logger.String("level", override.Level),
+		logger.String("route", override.Route),
+		logger.String("expires_at", override.ExpiresAt.Format(time.RFC3339)),
+	)
+	ctx.JSON(http.StatusOK, h.levels.Levels())
+}
+
+// Delete removes the override for the route given in the query string, or the global one.
+func (h *LogLevelHandler) Delete(ctx *context.GinContext) {
+	route := ctx.Query("route")
+	h.levels.ClearOverride(route)
+	logger.Warn(logger.GetLogContext(ctx.Context), "Log level override cleared", logger.String("route", route))
+	ctx.JSON(http.StatusOK, h.levels.Levels())
+}
+
+func (h *LogLevelHandler) sendErrorResponse(ctx *context.GinContext, message string, err error) {
+	ctx.JSON(http.StatusBadRequest, gin.H{
+		"error": message + err.Error(),
+	})
+}
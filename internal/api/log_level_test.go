@@ -0,0 +1,93 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ginContext "go-service-template/internal/infrastructure/context"
+	"go-service-template/internal/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLogLevelHandler(t *testing.T) (*LogLevelHandler, logger.LevelController) {
+	t.Helper()
+	t.Setenv(logger.LogLevelKey, logger.InfoKey)
+	levels, ok := logger.NewLogger("svc-test").(logger.LevelController)
+	require.True(t, ok)
+	return NewLogLevelHandler(levels), levels
+}
+
+func setupLogLevelContext(t *testing.T, method, target, body string) (*httptest.ResponseRecorder, *ginContext.GinContext) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	ctx, err := ginContext.NewGinContext(c)
+	require.NoError(t, err)
+	return w, ctx
+}
+
+func TestLogLevelHandler_Get_ReturnsLevels(t *testing.T) {
+	handler, _ := newLogLevelHandler(t)
+	w, ctx := setupLogLevelContext(t, http.MethodGet, "/admin/log-level", "")
+
+	handler.Get(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var state logger.LevelState
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &state))
+	assert.Equal(t, logger.InfoKey, state.Level)
+}
+
+func TestLogLevelHandler_Put_RouteOverride(t *testing.T) {
+	handler, levels := newLogLevelHandler(t)
+	w, ctx := setupLogLevelContext(t, http.MethodPut, "/admin/log-level",
+		`{"level":"debug","route":"/api/v1/limit/check","duration":"5m"}`)
+
+	handler.Put(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	overrides := levels.Levels().Overrides
+	require.Len(t, overrides, 1)
+	assert.Equal(t, "/api/v1/limit/check", overrides[0].Route)
+	assert.Equal(t, logger.DebugKey, overrides[0].Level)
+}
+
+func TestLogLevelHandler_Put_InvalidRequests_ReturnBadRequest(t *testing.T) {
+	tests := map[string]string{
+		"UnknownLevel":    `{"level":"loud"}`,
+		"InvalidDuration": `{"level":"debug","duration":"soon"}`,
+		"TooLong":         `{"level":"debug","duration":"48h"}`,
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler, levels := newLogLevelHandler(t)
+			w, ctx := setupLogLevelContext(t, http.MethodPut, "/admin/log-level", body)
+
+			handler.Put(ctx)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Empty(t, levels.Levels().Overrides)
+		})
+	}
+}
+
+func TestLogLevelHandler_Delete_ClearsOverride(t *testing.T) {
+	handler, levels := newLogLevelHandler(t)
+	_, err := levels.Override("/health", logger.DebugKey, 0)
+	require.NoError(t, err)
+	w, ctx := setupLogLevelContext(t, http.MethodDelete, "/admin/log-level?route=/health", "")
+
+	handler.Delete(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, levels.Levels().Overrides)
+}
@@ -37,7 +37,7 @@ func (api *userHandler) CreateUser(ctx *ginContext.GinContext) {
 		return
 	}
 
-	response, err := api.user.CreateUserRequest(&req)
+	response, err := api.user.CreateUserRequest(logCtx, &req)
 	if !api.handleUseCaseError(logCtx, ctx, err, "Failed to create user", req.ID) {
 		return
 	}
@@ -55,7 +55,7 @@ func (api *userHandler) FetchUser(ctx *ginContext.GinContext) {
 		return
 	}
 
-	user, err := api.user.FetchUser(&req)
+	user, err := api.user.FetchUser(logCtx, &req)
 	if !api.handleUseCaseError(logCtx, ctx, err, "Failed to fetch user", req.ID) {
 		return
 	}
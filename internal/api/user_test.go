@@ -48,7 +48,7 @@ func mockCreateUserUsecase(requestBody dto.CreateUserRequest) (*mocks.IUserUseCa
 	mockUseCase := &mocks.IUserUseCase{}
 	handler := NewUserHandler(mockUseCase)
 	expectedUser := user.CreateNewUser(requestBody)
-	mockUseCase.On("CreateUserRequest", mock.AnythingOfType("*dto.CreateUserRequest")).Return(expectedUser, nil)
+	mockUseCase.On("CreateUserRequest", mock.Anything, mock.AnythingOfType("*dto.CreateUserRequest")).Return(expectedUser, nil)
 	return mockUseCase, handler, expectedUser
 }
 
@@ -148,7 +148,7 @@ func TestUserHandler_CreateUser_UseCaseError_ReturnsInternalServerError(t *testi
 			mockUseCase := &mocks.IUserUseCase{}
 			handler := NewUserHandler(mockUseCase)
 
-			mockUseCase.On("CreateUserRequest", mock.AnythingOfType("*dto.CreateUserRequest")).Return((*user.User)(nil), tt.useCaseError)
+			mockUseCase.On("CreateUserRequest", mock.Anything, mock.AnythingOfType("*dto.CreateUserRequest")).Return((*user.User)(nil), tt.useCaseError)
 
 			requestBody := dto.CreateUserRequest{
 				ID:    123,
@@ -252,7 +252,7 @@ func TestUserHandler_CreateUser_ValidUserData_ReturnsSuccessResponse(t *testing.
 				Email: tt.expectedResponse["email"].(string),
 				Age:   int(tt.expectedResponse["age"].(float64)),
 			})
-			mockUseCase.On("CreateUserRequest", mock.AnythingOfType("*dto.CreateUserRequest")).Return(expectedUser, nil)
+			mockUseCase.On("CreateUserRequest", mock.Anything, mock.AnythingOfType("*dto.CreateUserRequest")).Return(expectedUser, nil)
 
 			w, ginCtx := setupUserTestContextWithJSON(t, tt.requestBody)
 
@@ -287,7 +287,7 @@ func TestUserHandler_FetchUser_ValidRequest_ReturnsOKResponse(t *testing.T) {
 		Email: expectedResponse["email"].(string),
 		Age:   int(expectedResponse["age"].(float64)),
 	})
-	mockUseCase.On("FetchUser", mock.AnythingOfType("*dto.FetchUserRequest")).Return(expectedUser, nil)
+	mockUseCase.On("FetchUser", mock.Anything, mock.AnythingOfType("*dto.FetchUserRequest")).Return(expectedUser, nil)
 
 	requestBody := dto.FetchUserRequest{ID: 123}
 	w, ginCtx := setupUserTestContextWithJSON(t, requestBody)
@@ -381,7 +381,7 @@ func TestUserHandler_FetchUser_UseCaseError_ReturnsInternalServerError(t *testin
 			mockUseCase := &mocks.IUserUseCase{}
 			handler := NewUserHandler(mockUseCase)
 
-			mockUseCase.On("FetchUser", mock.AnythingOfType("*dto.FetchUserRequest")).Return((*user.User)(nil), tt.useCaseError)
+			mockUseCase.On("FetchUser", mock.Anything, mock.AnythingOfType("*dto.FetchUserRequest")).Return((*user.User)(nil), tt.useCaseError)
 
 			requestBody := dto.FetchUserRequest{ID: 123}
 			w, ginCtx := setupUserTestContextWithJSON(t, requestBody)
@@ -450,7 +450,7 @@ func TestUserHandler_FetchUser_ValidUserIDs_ReturnsSuccessResponse(t *testing.T)
 				Email: tt.expectedResponse["email"].(string),
 				Age:   int(tt.expectedResponse["age"].(float64)),
 			})
-			mockUseCase.On("FetchUser", mock.AnythingOfType("*dto.FetchUserRequest")).Return(expectedUser, nil)
+			mockUseCase.On("FetchUser", mock.Anything, mock.AnythingOfType("*dto.FetchUserRequest")).Return(expectedUser, nil)
 
 			requestBody := dto.FetchUserRequest{ID: tt.userID}
 			w, ginCtx := setupUserTestContextWithJSON(t, requestBody)
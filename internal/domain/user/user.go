@@ -1,6 +1,12 @@
 package user
 
-import "go-service-template/internal/api/dto"
+import (
+	"strings"
+
+	"go-service-template/internal/api/dto"
+
+	"go.uber.org/zap/zapcore"
+)
 
 type User struct {
 	ID    int    `json:"id"`
@@ -17,3 +23,21 @@ func CreateNewUser(request dto.CreateUserRequest) *User {
 		Age:   request.Age,
 	}
 }
+
+// MarshalLogObject lets a User be logged as a field without exposing the raw email.
+func (u *User) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("id", u.ID)
+	enc.AddString("email", maskEmail(u.Email))
+	return nil
+}
+
+// maskEmail keeps the first character and the domain: "alice@example.com" -> "a***@example.com".
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return maskedValue
+	}
+	return email[:1] + maskedValue + email[at:]
+}
+
+const maskedValue = "***"
@@ -4,6 +4,9 @@ import (
     "testing"
 
     "go-service-template/internal/api/dto"
+
+    "github.com/stretchr/testify/assert"
+    "go.uber.org/zap/zapcore"
 )
 
 func TestCreateNewUser_BuildsFromDTO(t *testing.T) {
@@ -18,4 +21,20 @@ func TestCreateNewUser_BuildsFromDTO(t *testing.T) {
     }
 }
 
+func TestUser_MarshalLogObject_MasksEmail(t *testing.T) {
+    u := &User{ID: 7, Name: "Alice", Email: "alice@example.com", Age: 30}
+    enc := zapcore.NewMapObjectEncoder()
+
+    assert.NoError(t, u.MarshalLogObject(enc))
+
+    assert.Equal(t, map[string]interface{}{"id": 7, "email": "a***@example.com"}, enc.Fields)
+}
 
+func TestUser_MarshalLogObject_InvalidEmail(t *testing.T) {
+    u := &User{ID: 7, Email: "not-an-email"}
+    enc := zapcore.NewMapObjectEncoder()
+
+    assert.NoError(t, u.MarshalLogObject(enc))
+
+    assert.Equal(t, "***", enc.Fields["email"])
+}
@@ -0,0 +1,386 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/logger"
+	"go-service-template/internal/infrastructure/provider/redis"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// APIKey is an API key as stored; its value is only known to its holder.
+type APIKey struct {
+	ID     string   `json:"id"`
+	Hash   string   `json:"hash"`
+	Scopes []string `json:"scopes"`
+	// Tenant is the only tenant the key may act for; the default tenant when empty.
+	Tenant string `json:"tenant,omitempty"`
+	// ExpiresAt retires the key; it is never retired when zero.
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
+	// LastUsedAt is filled in by List, to the minute.
+	LastUsedAt time.Time `json:"last_used_at,omitzero"`
+	// Source is APIKeySourceConfig or APIKeySourceRedis.
+	Source string `json:"source"`
+}
+
+// Expired reports whether the key is no longer accepted at now.
+func (k *APIKey) Expired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+// APIKeySpec describes a key to issue.
+type APIKeySpec struct {
+	ID     string
+	Scopes []string
+	// Tenant is required: issued keys are always bound to a tenant.
+	Tenant    string
+	ExpiresAt time.Time
+	// Rotate retires the other keys of ID once Overlap has elapsed, giving their callers
+	// time to switch to the new key.
+	Rotate  bool
+	Overlap time.Duration
+}
+
+// APIKeyManager lists, issues and revokes API keys for the admin API.
+type APIKeyManager interface {
+	List(ctx context.Context) ([]APIKey, error)
+	// Issue stores a new key and returns its value, which cannot be retrieved afterwards.
+	Issue(ctx context.Context, spec *APIKeySpec) (string, *APIKey, error)
+	// Revoke deletes every key of id and returns how many there were.
+	Revoke(ctx context.Context, id string) (int, error)
+}
+
+// APIKeys authenticates API keys against the keys of the configuration, read on every call
+// so reloads rotate them, and, when enabled, against the keys stored in Redis. The last use
+// of every key is recorded in Redis, at most once per lastUsedResolution and instance.
+type APIKeys struct {
+	cfg   config.Provider
+	redis *redis.Provider
+	now   func() time.Time
+
+	mu      sync.Mutex
+	touched map[string]time.Time
+}
+
+var (
+	_ Authenticator = (*APIKeys)(nil)
+	_ APIKeyManager = (*APIKeys)(nil)
+)
+
+func NewAPIKeys(cfg config.Provider, redisProvider *redis.Provider) *APIKeys {
+	return &APIKeys{
+		cfg:     cfg,
+		redis:   redisProvider,
+		now:     time.Now,
+		touched: make(map[string]time.Time),
+	}
+}
+
+// HashAPIKey returns the hash under which key is stored.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateAPIKey returns a new random key.
+func GenerateAPIKey() (string, error) {
+	buf := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate API key: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// Authenticate returns the ID, scopes and tenant of key when it is known and not expired.
+func (k *APIKeys) Authenticate(ctx context.Context, key string) (*Claims, error) {
+	hash := HashAPIKey(key)
+	found, ok, err := k.find(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || found.Expired(k.now()) {
+		return nil, ErrInvalidAPIKey
+	}
+	k.touch(ctx, hash)
+	return &Claims{Method: MethodAPIKey, Subject: found.ID, Scopes: found.Scopes, Tenant: found.Tenant}, nil
+}
+
+func (k *APIKeys) find(ctx context.Context, hash string) (*APIKey, bool, error) {
+	cfg := k.cfg.GetAuthConfig().APIKeys
+	for i := range cfg.Keys {
+		if subtle.ConstantTimeCompare([]byte(cfg.Keys[i].Hash), []byte(hash)) == 1 {
+			return configAPIKey(&cfg.Keys[i]), true, nil
+		}
+	}
+	if !cfg.Redis {
+		return nil, false, nil
+	}
+	if !k.redis.Ready() {
+		return nil, false, fmt.Errorf("%w: Redis not ready", ErrUnavailable)
+	}
+	data, err := k.redis.GetClient().HGet(ctx, k.keysKey(), hash).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: find API key: %w", ErrUnavailable, err)
+	}
+	var key APIKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, false, fmt.Errorf("decode API key: %w", err)
+	}
+	return &key, true, nil
+}
+
+// touch records the use of hash. It is best effort: a failure only leaves an older time.
+func (k *APIKeys) touch(ctx context.Context, hash string) {
+	now := k.now()
+	k.mu.Lock()
+	if now.Sub(k.touched[hash]) < lastUsedResolution {
+		k.mu.Unlock()
+		return
+	}
+	k.touched[hash] = now
+	k.mu.Unlock()
+
+	if !k.redis.Ready() {
+		return
+	}
+	if err := k.redis.GetClient().HSet(ctx, k.lastUsedKey(), hash, now.UnixMilli()).Err(); err != nil {
+		logger.Warn(ctx, "Failed to record API key use",
+			logger.String(logger.FieldComponent, componentName),
+			logger.ErrorField(logger.FieldError, err),
+		)
+	}
+}
+
+// List returns the keys of the configuration and of Redis, expired ones included, with
+// their last use.
+func (k *APIKeys) List(ctx context.Context) ([]APIKey, error) {
+	cfg := k.cfg.GetAuthConfig().APIKeys
+	keys := make([]APIKey, 0, len(cfg.Keys))
+	for i := range cfg.Keys {
+		keys = append(keys, *configAPIKey(&cfg.Keys[i]))
+	}
+	if !k.redis.Ready() {
+		if cfg.Redis {
+			return nil, fmt.Errorf("%w: Redis not ready", ErrUnavailable)
+		}
+		return keys, nil
+	}
+	if cfg.Redis {
+		stored, err := k.storedKeys(ctx, k.redis.GetClient())
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, stored...)
+	}
+	lastUsed, err := k.redis.GetClient().HGetAll(ctx, k.lastUsedKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("%w: list API key uses: %w", ErrUnavailable, err)
+	}
+	for i := range keys {
+		if ms, err := strconv.ParseInt(lastUsed[keys[i].Hash], 10, 64); err == nil {
+			keys[i].LastUsedAt = time.UnixMilli(ms).UTC()
+		}
+	}
+	return keys, nil
+}
+
+// Issue stores a new key in Redis. With spec.Rotate, the other live keys of spec.ID are
+// given an expiry of spec.Overlap from now, unless they expire sooner. Expired keys are
+// deleted on the way.
+func (k *APIKeys) Issue(ctx context.Context, spec *APIKeySpec) (string, *APIKey, error) {
+	now := k.now()
+	if err := validateSpec(spec, now); err != nil {
+		return "", nil, err
+	}
+	value, err := GenerateAPIKey()
+	if err != nil {
+		return "", nil, err
+	}
+	issued := APIKey{
+		ID:        spec.ID,
+		Hash:      HashAPIKey(value),
+		Scopes:    spec.Scopes,
+		Tenant:    spec.Tenant,
+		ExpiresAt: spec.ExpiresAt,
+		CreatedAt: now,
+		Source:    APIKeySourceRedis,
+	}
+	err = k.update(ctx, func(stored []APIKey) ([]APIKey, []string) {
+		updates, expired := retireKeys(stored, spec, now)
+		return append(updates, issued), expired
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return value, &issued, nil
+}
+
+// retireKeys returns the stored keys of spec.ID given their rotation expiry, when spec
+// rotates, and the hashes of the keys that have already expired.
+func retireKeys(stored []APIKey, spec *APIKeySpec, now time.Time) (updates []APIKey, expired []string) {
+	for i := range stored {
+		key := &stored[i]
+		switch {
+		case key.Expired(now):
+			expired = append(expired, key.Hash)
+		case spec.Rotate && key.ID == spec.ID:
+			if retire := now.Add(spec.Overlap); key.ExpiresAt.IsZero() || key.ExpiresAt.After(retire) {
+				key.ExpiresAt = retire
+				updates = append(updates, *key)
+			}
+		}
+	}
+	return updates, expired
+}
+
+// Revoke deletes the keys of id from Redis. Keys listed in the configuration are revoked by
+// removing them from it.
+func (k *APIKeys) Revoke(ctx context.Context, id string) (int, error) {
+	var revoked []string
+	err := k.update(ctx, func(stored []APIKey) ([]APIKey, []string) {
+		revoked = nil
+		for i := range stored {
+			if stored[i].ID == id {
+				revoked = append(revoked, stored[i].Hash)
+			}
+		}
+		return nil, revoked
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(revoked), nil
+}
+
+func validateSpec(spec *APIKeySpec, now time.Time) error {
+	if !config.ValidAPIKeyID(spec.ID) || !config.ValidScopes(spec.Scopes) || !config.ValidTenantID(spec.Tenant) {
+		return fmt.Errorf("%w: invalid ID, scopes or tenant", ErrInvalidAPIKeySpec)
+	}
+	if (!spec.ExpiresAt.IsZero() && !spec.ExpiresAt.After(now)) || spec.Overlap < 0 {
+		return fmt.Errorf("%w: expiry must be in the future and overlap not negative", ErrInvalidAPIKeySpec)
+	}
+	return nil
+}
+
+// update applies change to the keys stored in Redis: change returns the keys to store and
+// the hashes of those to delete. The keys are watched from the read to the write, which is
+// retried from a fresh read when another instance changed them in between, so that no
+// concurrent issue or revocation is lost.
+func (k *APIKeys) update(ctx context.Context, change func(stored []APIKey) (updates []APIKey, hashes []string)) error {
+	if !k.cfg.GetAuthConfig().APIKeys.Redis {
+		return ErrAPIKeysReadOnly
+	}
+	if !k.redis.Ready() {
+		return fmt.Errorf("%w: Redis not ready", ErrUnavailable)
+	}
+	for range maxUpdateAttempts {
+		err := k.redis.GetClient().Watch(ctx, func(tx *goredis.Tx) error {
+			stored, err := k.storedKeys(ctx, tx)
+			if err != nil {
+				return err
+			}
+			updates, hashes := change(stored)
+			return k.write(ctx, tx, updates, hashes)
+		}, k.keysKey())
+		if !errors.Is(err, goredis.TxFailedErr) {
+			return err
+		}
+	}
+	return fmt.Errorf("%w: store API keys: too many concurrent updates", ErrUnavailable)
+}
+
+func (k *APIKeys) storedKeys(ctx context.Context, client goredis.Cmdable) ([]APIKey, error) {
+	values, err := client.HGetAll(ctx, k.keysKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("%w: list API keys: %w", ErrUnavailable, err)
+	}
+	keys := make([]APIKey, 0, len(values))
+	for _, value := range values {
+		var key APIKey
+		if err := json.Unmarshal([]byte(value), &key); err != nil {
+			return nil, fmt.Errorf("decode API key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// write stores updates and deletes the keys of hashes in one transaction, which fails
+// with goredis.TxFailedErr when the keys changed since tx started watching them.
+func (k *APIKeys) write(ctx context.Context, tx *goredis.Tx, updates []APIKey, hashes []string) error {
+	values := make(map[string]any, len(updates))
+	for i := range updates {
+		data, err := json.Marshal(&updates[i])
+		if err != nil {
+			return fmt.Errorf("encode API key: %w", err)
+		}
+		values[updates[i].Hash] = data
+	}
+	_, err := tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		if len(hashes) > 0 {
+			pipe.HDel(ctx, k.keysKey(), hashes...)
+		}
+		if len(values) > 0 {
+			pipe.HSet(ctx, k.keysKey(), values)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: store API keys: %w", ErrUnavailable, err)
+	}
+	return nil
+}
+
+// keysKey holds every stored key, by hash, in a single Redis hash so that it works
+// unchanged on a cluster. API keys are not tenant scoped and live in the default tenant.
+func (k *APIKeys) keysKey() string {
+	return k.redis.Keys().Key(redis.DefaultTenant, apiKeysKeyPart)
+}
+
+func (k *APIKeys) lastUsedKey() string {
+	return k.redis.Keys().Key(redis.DefaultTenant, apiKeysKeyPart, lastUsedKeyPart)
+}
+
+func configAPIKey(key *config.APIKeyConfig) *APIKey {
+	return &APIKey{
+		ID:        key.ID,
+		Hash:      key.Hash,
+		Scopes:    key.Scopes,
+		Tenant:    key.Tenant,
+		ExpiresAt: key.ExpiresAt,
+		Source:    APIKeySourceConfig,
+	}
+}
+
+var (
+	ErrAPIKeysReadOnly   = errors.New("API keys are managed in the configuration file")
+	ErrInvalidAPIKeySpec = errors.New("invalid API key settings")
+)
+
+const (
+	APIKeySourceConfig = "config"
+	APIKeySourceRedis  = "redis"
+	apiKeyBytes        = 32
+	apiKeysKeyPart     = "apikeys"
+	lastUsedKeyPart    = "last_used"
+	lastUsedResolution = time.Minute
+	// maxUpdateAttempts bounds the retries of an update racing with other writers.
+	maxUpdateAttempts = 5
+)
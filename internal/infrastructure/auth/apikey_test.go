@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/provider/redis"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRedisAPIKeys(t *testing.T, cfg *config.Config) *APIKeys {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	cfg.Redis.Host = mr.Addr()
+	provider, err := redis.NewProvider(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = provider.Close() })
+	require.NoError(t, provider.Ping(context.Background()))
+	return NewAPIKeys(cfg, provider)
+}
+
+func TestAPIKeys_ConfigKey_Authenticates(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Auth.APIKeys.Keys = []config.APIKeyConfig{
+		{ID: "billing", Hash: HashAPIKey("live-key"), Scopes: []string{ScopeLimitCheck}},
+		{ID: "billing", Hash: HashAPIKey("old-key"), Scopes: []string{ScopeLimitCheck}, ExpiresAt: time.Now().Add(-time.Minute)},
+	}
+	keys := NewAPIKeys(cfg, nil)
+
+	claims, err := keys.Authenticate(context.Background(), "live-key")
+	require.NoError(t, err)
+	assert.Equal(t, &Claims{Method: MethodAPIKey, Subject: "billing", Scopes: []string{ScopeLimitCheck}}, claims)
+
+	_, err = keys.Authenticate(context.Background(), "old-key")
+	require.ErrorIs(t, err, ErrInvalidAPIKey, "expired keys are refused")
+	_, err = keys.Authenticate(context.Background(), "unknown")
+	require.ErrorIs(t, err, ErrInvalidAPIKey)
+	_, _, err = keys.Issue(context.Background(), &APIKeySpec{ID: "billing", Scopes: []string{ScopeLimitCheck}, Tenant: "acme"})
+	assert.ErrorIs(t, err, ErrAPIKeysReadOnly)
+}
+
+func TestAPIKeys_Redis_IssueAuthenticateRevoke(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Auth.APIKeys.Redis = true
+	keys := newRedisAPIKeys(t, cfg)
+	ctx := context.Background()
+
+	value, issued, err := keys.Issue(ctx, &APIKeySpec{ID: "billing", Scopes: []string{ScopeLimitCheck, ScopeLimitReset}, Tenant: "acme"})
+	require.NoError(t, err)
+	assert.Equal(t, HashAPIKey(value), issued.Hash)
+
+	claims, err := keys.Authenticate(ctx, value)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ScopeLimitCheck, ScopeLimitReset}, claims.Scopes)
+	assert.Equal(t, "acme", claims.Tenant)
+
+	revoked, err := keys.Revoke(ctx, "billing")
+	require.NoError(t, err)
+	assert.Equal(t, 1, revoked)
+	_, err = keys.Authenticate(ctx, value)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+}
+
+func TestAPIKeys_Rotate_KeepsOldKeyForOverlap(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Auth.APIKeys.Redis = true
+	keys := newRedisAPIKeys(t, cfg)
+	now := time.Now()
+	keys.now = func() time.Time { return now }
+	ctx := context.Background()
+	oldValue, _, err := keys.Issue(ctx, &APIKeySpec{ID: "billing", Scopes: []string{ScopeLimitCheck}, Tenant: "acme"})
+	require.NoError(t, err)
+
+	newValue, _, err := keys.Issue(ctx, &APIKeySpec{ID: "billing", Scopes: []string{ScopeLimitCheck}, Tenant: "acme", Rotate: true, Overlap: time.Hour})
+	require.NoError(t, err)
+
+	_, err = keys.Authenticate(ctx, oldValue)
+	require.NoError(t, err, "the old key is accepted during the overlap")
+	now = now.Add(time.Hour)
+	_, err = keys.Authenticate(ctx, oldValue)
+	require.ErrorIs(t, err, ErrInvalidAPIKey)
+	_, err = keys.Authenticate(ctx, newValue)
+	assert.NoError(t, err)
+}
+
+// revokeOnRead deletes hash with another connection right after the first read of the
+// stored keys, as a concurrent revocation on another instance would.
+type revokeOnRead struct {
+	client goredis.UniversalClient
+	key    string
+	hash   string
+	done   bool
+}
+
+func (h *revokeOnRead) DialHook(next goredis.DialHook) goredis.DialHook { return next }
+
+func (h *revokeOnRead) ProcessPipelineHook(next goredis.ProcessPipelineHook) goredis.ProcessPipelineHook {
+	return next
+}
+
+func (h *revokeOnRead) ProcessHook(next goredis.ProcessHook) goredis.ProcessHook {
+	return func(ctx context.Context, cmd goredis.Cmder) error {
+		err := next(ctx, cmd)
+		if cmd.Name() == "hgetall" && !h.done {
+			h.done = true
+			h.client.HDel(ctx, h.key, h.hash)
+		}
+		return err
+	}
+}
+
+func TestAPIKeys_Rotate_RetriesAfterConcurrentRevocation(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Auth.APIKeys.Redis = true
+	keys := newRedisAPIKeys(t, cfg)
+	ctx := context.Background()
+	oldValue, _, err := keys.Issue(ctx, &APIKeySpec{ID: "billing", Scopes: []string{ScopeLimitCheck}, Tenant: "acme"})
+	require.NoError(t, err)
+	other := goredis.NewClient(&goredis.Options{Addr: cfg.Redis.Host})
+	t.Cleanup(func() { _ = other.Close() })
+	keys.redis.GetClient().AddHook(&revokeOnRead{client: other, key: keys.keysKey(), hash: HashAPIKey(oldValue)})
+
+	_, _, err = keys.Issue(ctx, &APIKeySpec{ID: "billing", Scopes: []string{ScopeLimitCheck}, Tenant: "acme", Rotate: true, Overlap: time.Hour})
+	require.NoError(t, err)
+
+	_, err = keys.Authenticate(ctx, oldValue)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey, "the rotation does not restore the revoked key")
+}
+
+func TestAPIKeys_List_TracksLastUse(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Auth.APIKeys.Keys = []config.APIKeyConfig{{ID: "billing", Hash: HashAPIKey("live-key"), Scopes: []string{ScopeLimitCheck}}}
+	keys := newRedisAPIKeys(t, cfg)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	keys.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := keys.Authenticate(ctx, "live-key")
+	require.NoError(t, err)
+	now = now.Add(time.Second)
+	_, err = keys.Authenticate(ctx, "live-key")
+	require.NoError(t, err)
+	listed, err := keys.List(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, APIKeySourceConfig, listed[0].Source)
+	assert.Equal(t, now.Add(-time.Second), listed[0].LastUsedAt, "uses are recorded at most once per minute")
+}
+
+func TestAPIKeys_RedisNotReady_Unavailable(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Auth.APIKeys.Redis = true
+	keys := NewAPIKeys(cfg, nil)
+
+	_, err := keys.Authenticate(context.Background(), "any-key")
+
+	assert.ErrorIs(t, err, ErrUnavailable)
+}
+
+func TestAPIKeys_Issue_InvalidSpec(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Auth.APIKeys.Redis = true
+	keys := newRedisAPIKeys(t, cfg)
+
+	_, _, err := keys.Issue(context.Background(), &APIKeySpec{ID: "billing", Scopes: []string{"everything"}})
+
+	assert.ErrorIs(t, err, ErrInvalidAPIKeySpec)
+}
+
+func TestAPIKeys_Issue_RequiresTenant(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Auth.APIKeys.Redis = true
+	keys := newRedisAPIKeys(t, cfg)
+
+	_, _, err := keys.Issue(context.Background(), &APIKeySpec{ID: "billing", Scopes: []string{ScopeLimitCheck}})
+
+	assert.ErrorIs(t, err, ErrInvalidAPIKeySpec)
+}
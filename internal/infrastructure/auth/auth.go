@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Authenticator verifies the bearer token of a request and returns its claims.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Claims, error)
+}
+
+// Claims describe the authenticated caller of a request: the claims of its token, or the
+// ID and scopes of its API key.
+type Claims struct {
+	// Method is MethodJWT or MethodAPIKey.
+	Method string
+	// Subject is the "sub" claim of a token, or the ID of an API key.
+	Subject string
+	// Scopes are the "scope" or "scp" claim of a token, or the scopes of an API key.
+	Scopes []string
+	// Roles are the "roles" claim of a token; API keys have none.
+	Roles []string
+	// Tenant is the "tenant" claim of a token, or the tenant of an API key: the only tenant
+	// the caller may act for. Callers without one may only act for the default tenant.
+	Tenant   string
+	Issuer   string
+	Audience []string
+	Expiry   time.Time
+	// Raw holds every claim of a token, the registered ones included, as decoded from JSON.
+	Raw map[string]any
+}
+
+// WithClaims returns a copy of ctx carrying claims.
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext returns the claims attached to ctx by Middleware, or nil when the request
+// was not authenticated.
+func FromContext(ctx context.Context) *Claims {
+	if ctx == nil {
+		return nil
+	}
+	if claims, ok := ctx.Value(claimsKey{}).(*Claims); ok {
+		return claims
+	}
+	return nil
+}
+
+// SubjectFromContext returns the subject of the claims attached to ctx, or "".
+func SubjectFromContext(ctx context.Context) string {
+	if claims := FromContext(ctx); claims != nil {
+		return claims.Subject
+	}
+	return ""
+}
+
+type claimsKey struct{}
+
+var (
+	ErrInvalidToken    = errors.New("invalid token")
+	ErrUnknownKey      = errors.New("unknown token signing key")
+	ErrJWKSUnavailable = errors.New("JWKS unavailable")
+	ErrInvalidAPIKey   = errors.New("invalid API key")
+	// ErrUnavailable reports that a credential could not be checked, rather than refused.
+	ErrUnavailable = errors.New("authentication unavailable")
+)
+
+const (
+	MethodJWT    = "jwt"
+	MethodAPIKey = "api_key"
+)
+
+// Scopes granted to API keys.
+const (
+	ScopeLimitCheck = "limit:check"
+	ScopeLimitReset = "limit:reset"
+	ScopeUserWrite  = "user:write"
+)
@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext_WithClaims_ReturnsClaims(t *testing.T) {
+	claims := &Claims{Subject: "user-1"}
+	ctx := WithClaims(context.Background(), claims)
+
+	assert.Same(t, claims, FromContext(ctx))
+	assert.Equal(t, "user-1", SubjectFromContext(ctx))
+}
+
+func TestFromContext_NoClaims_ReturnsNil(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+	assert.Empty(t, SubjectFromContext(context.Background()))
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-service-template/internal/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveAuthorized(claims *Claims, requirement Requirement) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(logger.LoggingMiddleware(), func(c *gin.Context) {
+		if claims != nil {
+			logger.SetLogContext(WithClaims(logger.GetLogContext(c), claims), c)
+		}
+	})
+	r.POST("/api/v1/limit/reset", Require(requirement), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/limit/reset", nil))
+	return w
+}
+
+func TestRequirement_Allows(t *testing.T) {
+	reset := Requirement{Scopes: []string{ScopeLimitReset}, Roles: []string{RoleAdmin}}
+	tests := []struct {
+		name        string
+		requirement Requirement
+		claims      *Claims
+		allowed     bool
+	}{
+		{name: "Unauthenticated", requirement: Requirement{}, claims: nil, allowed: false},
+		{name: "AnyCaller", requirement: Requirement{}, claims: &Claims{}, allowed: true},
+		{name: "Scope", requirement: reset, claims: &Claims{Scopes: []string{ScopeLimitCheck, ScopeLimitReset}}, allowed: true},
+		{name: "MissingScope", requirement: reset, claims: &Claims{Scopes: []string{ScopeLimitCheck}}, allowed: false},
+		{name: "Role", requirement: reset, claims: &Claims{Roles: []string{RoleAdmin}}, allowed: true},
+		{name: "OtherRole", requirement: reset, claims: &Claims{Roles: []string{"viewer"}}, allowed: false},
+		{name: "EveryScope", requirement: Requirement{Scopes: []string{ScopeLimitReset, ScopeUserWrite}},
+			claims: &Claims{Scopes: []string{ScopeUserWrite}}, allowed: false},
+		{name: "RoleOnly", requirement: Requirement{Roles: []string{RoleAdmin}}, claims: &Claims{}, allowed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, tt.requirement.Allows(tt.claims))
+		})
+	}
+}
+
+func TestRequire_Allowed_CallsHandler(t *testing.T) {
+	w := serveAuthorized(&Claims{Method: MethodAPIKey, Scopes: []string{ScopeLimitReset}},
+		Requirement{Scopes: []string{ScopeLimitReset}})
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestRequire_Denied_ProblemResponse(t *testing.T) {
+	w := serveAuthorized(&Claims{Method: MethodJWT, Subject: "user-1", Scopes: []string{ScopeLimitCheck}},
+		Requirement{Scopes: []string{ScopeLimitReset}, Roles: []string{RoleAdmin}})
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, `Bearer error="insufficient_scope", scope="limit:reset"`, w.Header().Get("WWW-Authenticate"))
+	var problem Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, Problem{
+		Type:     "about:blank",
+		Title:    "Forbidden",
+		Status:   http.StatusForbidden,
+		Detail:   "requires scopes limit:reset or one of the roles admin",
+		Instance: "/api/v1/limit/reset",
+	}, problem)
+}
+
+func TestRequire_APIKeyDenied_NoBearerChallenge(t *testing.T) {
+	w := serveAuthorized(&Claims{Method: MethodAPIKey, Subject: "billing"}, Requirement{Scopes: []string{ScopeLimitReset}})
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, w.Header().Get("WWW-Authenticate"))
+}
+
+func TestRequire_Unauthenticated_Forbidden(t *testing.T) {
+	w := serveAuthorized(nil, Requirement{})
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/logger"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/sync/singleflight"
+)
+
+// keySet caches the keys of a JWKS. They are reloaded once they are older than refresh,
+// and when a token names a key the set does not hold, at most every minReloadInterval, so
+// keys rotated in by the issuer are found without waiting for the refresh. A failed load
+// keeps the previous keys.
+//
+// Loads run outside the lock, once for all the requests waiting for them, and detached
+// from the request that started them: a slow issuer only delays the requests that need a
+// reload, and a canceled request does not abort the load the others wait for.
+type keySet struct {
+	load    func(ctx context.Context) ([]byte, error)
+	refresh time.Duration
+	now     func() time.Time
+	loads   singleflight.Group
+
+	mu          sync.Mutex
+	keys        []jose.JSONWebKey
+	loadErr     error
+	loadedAt    time.Time
+	attemptedAt time.Time
+}
+
+func newKeySet(cfg *config.JWTConfig) *keySet {
+	s := &keySet{refresh: cfg.JWKSRefresh, now: time.Now}
+	if cfg.JWKSFile != "" {
+		path := cfg.JWKSFile
+		s.load = func(context.Context) ([]byte, error) { return os.ReadFile(path) }
+	} else {
+		s.load = fetchJWKS(cfg.JWKSURL)
+	}
+	return s
+}
+
+// key returns the public key kid for alg. A token without a kid uses the first key that
+// may sign with alg. When the key is missing because the JWKS could not be loaded, the
+// error wraps ErrUnavailable rather than ErrUnknownKey.
+func (s *keySet) key(ctx context.Context, kid, alg string) (any, error) {
+	if s.due(s.refresh) {
+		s.reload(ctx)
+	}
+	key, ok := s.find(kid, alg)
+	if !ok && s.due(0) {
+		s.reload(ctx)
+		key, ok = s.find(kid, alg)
+	}
+	if ok {
+		return key, nil
+	}
+	if err := s.failure(); err != nil {
+		return nil, fmt.Errorf("%w: %w: %w", ErrUnavailable, ErrJWKSUnavailable, err)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+}
+
+// due reports whether the keys are older than age and no load was attempted in the last
+// minReloadInterval.
+func (s *keySet) due(age time.Duration) bool {
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return now.Sub(s.loadedAt) >= age && now.Sub(s.attemptedAt) >= minReloadInterval
+}
+
+// failure returns the error of the last load, nil when it succeeded.
+func (s *keySet) failure() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loadErr
+}
+
+// reload loads the keys, joining the load already running if any, and waits for it until
+// ctx is done. The load itself is bounded by fetchTimeout only.
+func (s *keySet) reload(ctx context.Context) {
+	done := s.loads.DoChan(jwksLoadKey, func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+		s.loadKeys(loadCtx)
+		return nil, nil
+	})
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+func (s *keySet) loadKeys(ctx context.Context) {
+	now := s.now()
+	data, err := s.load(ctx)
+	var keys jose.JSONWebKeySet
+	if err == nil {
+		err = json.Unmarshal(data, &keys)
+	}
+
+	// The attempt is only recorded once done, so requests arriving meanwhile join the load.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attemptedAt = now
+	s.loadErr = err
+	if err != nil {
+		logger.Warn(ctx, "Failed to load JWKS",
+			logger.String(logger.FieldComponent, componentName),
+			logger.ErrorField(logger.FieldError, err),
+		)
+		return
+	}
+	s.keys = keys.Keys
+	s.loadedAt = now
+}
+
+func (s *keySet) find(kid, alg string) (any, bool) {
+	s.mu.Lock()
+	keys := s.keys
+	s.mu.Unlock()
+	for i := range keys {
+		jwk := &keys[i]
+		if (kid != "" && jwk.KeyID != kid) || (jwk.Use != "" && jwk.Use != keyUseSignature) ||
+			(jwk.Algorithm != "" && jwk.Algorithm != alg) {
+			continue
+		}
+		// Public drops the private part of keys published by mistake.
+		if public := jwk.Public(); public.Valid() {
+			return public.Key, true
+		}
+	}
+	return nil, false
+}
+
+// fetchJWKS returns a loader getting the JWKS from url. Fetches are traced as child spans
+// of the request that started the load.
+func fetchJWKS(url string) func(ctx context.Context) ([]byte, error) {
+	client := &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport), Timeout: fetchTimeout}
+	return func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return nil, fmt.Errorf("fetch JWKS: %w", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("fetch JWKS: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%w: GET %s returned %d", ErrJWKSUnavailable, url, resp.StatusCode)
+		}
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxJWKSBytes))
+		if err != nil {
+			return nil, fmt.Errorf("read JWKS: %w", err)
+		}
+		return data, nil
+	}
+}
+
+const (
+	componentName     = "auth"
+	keyUseSignature   = "sig"
+	minReloadInterval = 10 * time.Second
+	jwksLoadKey       = "jwks"
+	fetchTimeout      = 5 * time.Second
+	maxJWKSBytes      = 1 << 20
+)
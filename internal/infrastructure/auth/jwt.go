@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-service-template/internal/infrastructure/config"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+// JWTAuthenticator verifies JSON Web Tokens signed with HS256 by the shared secret, or with
+// RS256 or ES256 by a key of the JWKS. Only the algorithms it has keys for are accepted, so
+// a public key can never be used as an HMAC secret.
+type JWTAuthenticator struct {
+	secret     []byte
+	keys       *keySet
+	algorithms []jose.SignatureAlgorithm
+	issuer     string
+	audience   string
+	leeway     time.Duration
+	now        func() time.Time
+}
+
+var _ Authenticator = (*JWTAuthenticator)(nil)
+
+// NewJWTAuthenticator creates the authenticator of cfg. The JWKS is loaded on the first
+// token that needs it; until it loads, those tokens are rejected.
+func NewJWTAuthenticator(cfg *config.JWTConfig) *JWTAuthenticator {
+	a := &JWTAuthenticator{
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+		leeway:   cfg.Leeway,
+		now:      time.Now,
+	}
+	if cfg.Secret != "" {
+		a.secret = []byte(cfg.Secret)
+		a.algorithms = append(a.algorithms, jose.HS256)
+	}
+	if cfg.JWKS() != "" {
+		a.keys = newKeySet(cfg)
+		a.algorithms = append(a.algorithms, jose.RS256, jose.ES256)
+	}
+	return a
+}
+
+// Authenticate verifies the signature of token, then its expiry, which is required, its
+// not-before time, and its issuer and audience when configured.
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, token string) (*Claims, error) {
+	parsed, err := jwt.ParseSigned(token, a.algorithms)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	key, err := a.key(ctx, &parsed.Headers[0])
+	if err != nil {
+		return nil, err
+	}
+	var registered jwt.Claims
+	raw := map[string]any{}
+	if err := parsed.Claims(key, &registered, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if registered.Expiry == nil {
+		return nil, fmt.Errorf("%w: no expiry", ErrInvalidToken)
+	}
+	expected := jwt.Expected{Issuer: a.issuer, Time: a.now()}
+	if a.audience != "" {
+		expected.AnyAudience = jwt.Audience{a.audience}
+	}
+	if err := registered.ValidateWithLeeway(expected, a.leeway); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	return &Claims{
+		Method:   MethodJWT,
+		Subject:  registered.Subject,
+		Scopes:   tokenScopes(raw),
+		Roles:    stringsClaim(raw["roles"]),
+		Tenant:   stringClaim(raw["tenant"]),
+		Issuer:   registered.Issuer,
+		Audience: registered.Audience,
+		Expiry:   registered.Expiry.Time(),
+		Raw:      raw,
+	}, nil
+}
+
+// tokenScopes returns the scopes of a token: its space-delimited "scope" claim (RFC 8693),
+// or the "scp" claim some issuers send instead, as a string or an array.
+func tokenScopes(raw map[string]any) []string {
+	if scopes := stringsClaim(raw["scope"]); len(scopes) > 0 {
+		return scopes
+	}
+	return stringsClaim(raw["scp"])
+}
+
+// stringClaim returns a claim holding a string, or "".
+func stringClaim(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return ""
+}
+
+// stringsClaim returns a claim holding either a space-delimited string or an array of
+// strings. Other values are ignored.
+func stringsClaim(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// key returns the key verifying a token with header. ParseSigned already refused the
+// algorithms without a key.
+func (a *JWTAuthenticator) key(ctx context.Context, header *jose.Header) (any, error) {
+	if header.Algorithm == string(jose.HS256) {
+		return a.secret, nil
+	}
+	return a.keys.key(ctx, header.KeyID, header.Algorithm)
+}
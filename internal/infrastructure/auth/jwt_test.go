@@ -0,0 +1,276 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-service-template/internal/infrastructure/config"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func sign(t *testing.T, alg jose.SignatureAlgorithm, key any, kid string, claims ...any) string {
+	t.Helper()
+	opts := (&jose.SignerOptions{}).WithType("JWT")
+	if kid != "" {
+		opts = opts.WithHeader(jose.HeaderKey("kid"), kid)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, opts)
+	require.NoError(t, err)
+	builder := jwt.Signed(signer)
+	for _, c := range claims {
+		builder = builder.Claims(c)
+	}
+	token, err := builder.Serialize()
+	require.NoError(t, err)
+	return token
+}
+
+func validClaims() jwt.Claims {
+	return jwt.Claims{
+		Subject:  "user-1",
+		Issuer:   "https://issuer.example",
+		Audience: jwt.Audience{"go-service-template"},
+		Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func jwksJSON(t *testing.T, keys ...jose.JSONWebKey) []byte {
+	t.Helper()
+	data, err := json.Marshal(jose.JSONWebKeySet{Keys: keys})
+	require.NoError(t, err)
+	return data
+}
+
+func secretConfig() *config.JWTConfig {
+	return &config.JWTConfig{
+		Secret:      testSecret,
+		Issuer:      "https://issuer.example",
+		Audience:    "go-service-template",
+		JWKSRefresh: time.Minute,
+	}
+}
+
+func TestJWTAuthenticator_HS256_ReturnsClaims(t *testing.T) {
+	a := NewJWTAuthenticator(secretConfig())
+	token := sign(t, jose.HS256, []byte(testSecret), "", validClaims(), map[string]any{"scope": "limits:read"})
+
+	claims, err := a.Authenticate(context.Background(), token)
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Equal(t, []string{"go-service-template"}, claims.Audience)
+	assert.Equal(t, "limits:read", claims.Raw["scope"])
+}
+
+func TestJWTAuthenticator_TenantClaim(t *testing.T) {
+	a := NewJWTAuthenticator(secretConfig())
+	token := sign(t, jose.HS256, []byte(testSecret), "", validClaims(), map[string]any{"tenant": "acme"})
+
+	claims, err := a.Authenticate(context.Background(), token)
+
+	require.NoError(t, err)
+	assert.Equal(t, "acme", claims.Tenant)
+}
+
+func TestJWTAuthenticator_ScopesAndRoles(t *testing.T) {
+	a := NewJWTAuthenticator(secretConfig())
+	tests := []struct {
+		name   string
+		claims map[string]any
+		scopes []string
+		roles  []string
+	}{
+		{name: "ScopeString", claims: map[string]any{"scope": "limit:check  limit:reset"}, scopes: []string{"limit:check", "limit:reset"}},
+		{name: "ScpArray", claims: map[string]any{"scp": []string{"user:write"}}, scopes: []string{"user:write"}},
+		{name: "Roles", claims: map[string]any{"roles": []any{"admin", 7}}, roles: []string{"admin"}},
+		{name: "None", claims: map[string]any{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := a.Authenticate(context.Background(), sign(t, jose.HS256, []byte(testSecret), "", validClaims(), tt.claims))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.scopes, claims.Scopes)
+			assert.Equal(t, tt.roles, claims.Roles)
+		})
+	}
+}
+
+func TestJWTAuthenticator_RejectsInvalidTokens(t *testing.T) {
+	expired := validClaims()
+	expired.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	wrongIssuer := validClaims()
+	wrongIssuer.Issuer = "https://other.example"
+	wrongAudience := validClaims()
+	wrongAudience.Audience = jwt.Audience{"other-service"}
+	noExpiry := validClaims()
+	noExpiry.Expiry = nil
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := map[string]string{
+		"Malformed":      "not-a-token",
+		"WrongSecret":    sign(t, jose.HS256, []byte("another-secret-of-at-least-32-bytes"), "", validClaims()),
+		"Expired":        sign(t, jose.HS256, []byte(testSecret), "", expired),
+		"WrongIssuer":    sign(t, jose.HS256, []byte(testSecret), "", wrongIssuer),
+		"WrongAudience":  sign(t, jose.HS256, []byte(testSecret), "", wrongAudience),
+		"NoExpiry":       sign(t, jose.HS256, []byte(testSecret), "", noExpiry),
+		"AlgWithoutKeys": sign(t, jose.RS256, rsaKey, "", validClaims()),
+	}
+	a := NewJWTAuthenticator(secretConfig())
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := a.Authenticate(context.Background(), token)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestJWTAuthenticator_Leeway_AcceptsRecentlyExpiredToken(t *testing.T) {
+	cfg := secretConfig()
+	cfg.Leeway = time.Minute
+	claims := validClaims()
+	claims.Expiry = jwt.NewNumericDate(time.Now().Add(-30 * time.Second))
+
+	_, err := NewJWTAuthenticator(cfg).Authenticate(context.Background(), sign(t, jose.HS256, []byte(testSecret), "", claims))
+
+	assert.NoError(t, err)
+}
+
+func TestJWTAuthenticator_RS256_JWKSFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksJSON(t, jose.JSONWebKey{Key: key.Public(), KeyID: "k1", Algorithm: "RS256", Use: "sig"}), 0o600))
+	a := NewJWTAuthenticator(&config.JWTConfig{JWKSFile: path, JWKSRefresh: time.Minute})
+
+	claims, err := a.Authenticate(context.Background(), sign(t, jose.RS256, key, "k1", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+
+	_, err = a.Authenticate(context.Background(), sign(t, jose.HS256, []byte(testSecret), "", validClaims()))
+	assert.ErrorIs(t, err, ErrInvalidToken, "HS256 is refused without a secret")
+}
+
+func TestJWTAuthenticator_ES256_JWKSURL_CachesAndRotatesKeys(t *testing.T) {
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	var fetches atomic.Int32
+	published := jwksJSON(t, jose.JSONWebKey{Key: oldKey.Public(), KeyID: "old"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		_, _ = w.Write(published)
+	}))
+	defer server.Close()
+	a := NewJWTAuthenticator(&config.JWTConfig{JWKSURL: server.URL, JWKSRefresh: time.Hour})
+	now := time.Now()
+	a.keys.now = func() time.Time { return now }
+
+	_, err = a.Authenticate(context.Background(), sign(t, jose.ES256, oldKey, "old", validClaims()))
+	require.NoError(t, err)
+	_, err = a.Authenticate(context.Background(), sign(t, jose.ES256, oldKey, "old", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), fetches.Load(), "keys are cached")
+
+	published = jwksJSON(t, jose.JSONWebKey{Key: oldKey.Public(), KeyID: "old"}, jose.JSONWebKey{Key: newKey.Public(), KeyID: "new"})
+	_, err = a.Authenticate(context.Background(), sign(t, jose.ES256, newKey, "new", validClaims()))
+	assert.ErrorIs(t, err, ErrUnknownKey, "unknown keys are not refetched more often than minReloadInterval")
+
+	now = now.Add(minReloadInterval)
+	_, err = a.Authenticate(context.Background(), sign(t, jose.ES256, newKey, "new", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestKeySet_FailedReload_KeepsPreviousKeys(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write(jwksJSON(t, jose.JSONWebKey{Key: key.Public(), KeyID: "k1"}))
+	}))
+	defer server.Close()
+	a := NewJWTAuthenticator(&config.JWTConfig{JWKSURL: server.URL, JWKSRefresh: time.Minute})
+	now := time.Now()
+	a.keys.now = func() time.Time { return now }
+	token := sign(t, jose.ES256, key, "k1", validClaims())
+	_, err = a.Authenticate(context.Background(), token)
+	require.NoError(t, err)
+
+	status = http.StatusServiceUnavailable
+	now = now.Add(time.Hour)
+	_, err = a.Authenticate(context.Background(), token)
+
+	assert.NoError(t, err)
+}
+
+func TestKeySet_JWKSOutage_Unavailable(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	a := NewJWTAuthenticator(&config.JWTConfig{JWKSURL: server.URL, JWKSRefresh: time.Minute})
+
+	_, err = a.Authenticate(context.Background(), sign(t, jose.ES256, key, "k1", validClaims()))
+
+	require.ErrorIs(t, err, ErrUnavailable)
+	assert.NotErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestKeySet_CancelledRequest_DoesNotAbortSharedLoad(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	requested, release := make(chan struct{}), make(chan struct{})
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if fetches.Add(1) == 1 {
+			close(requested)
+		}
+		<-release
+		_, _ = w.Write(jwksJSON(t, jose.JSONWebKey{Key: key.Public(), KeyID: "k1"}))
+	}))
+	defer server.Close()
+	a := NewJWTAuthenticator(&config.JWTConfig{JWKSURL: server.URL, JWKSRefresh: time.Minute})
+	token := sign(t, jose.ES256, key, "k1", validClaims())
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := a.Authenticate(cancelled, token)
+		first <- err
+	}()
+	<-requested
+	cancel()
+	require.Error(t, <-first, "the cancelled request stops waiting")
+	second := make(chan error, 1)
+	go func() {
+		_, err := a.Authenticate(context.Background(), token)
+		second <- err
+	}()
+	close(release)
+
+	require.NoError(t, <-second)
+	assert.Equal(t, int32(1), fetches.Load(), "both requests share one fetch")
+}
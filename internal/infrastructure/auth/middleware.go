@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"go-service-template/internal/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Authenticators are the credentials API requests may authenticate with; the nil ones are
+// not accepted.
+type Authenticators struct {
+	// Bearer verifies "Authorization: Bearer" tokens.
+	Bearer Authenticator
+	// APIKey verifies the key sent in the APIKeyHeader header.
+	APIKey       Authenticator
+	APIKeyHeader string
+}
+
+// Enabled reports whether any credential is accepted.
+func (a *Authenticators) Enabled() bool {
+	return a.Bearer != nil || a.APIKey != nil
+}
+
+// Middleware authenticates the API key or, without one, the bearer token of the request
+// and attaches the resulting claims to the request context, the subject and method to the
+// log fields and the request span. It must run after logger.LoggingMiddleware.
+//
+// Requests without valid credentials are rejected with 401 and a WWW-Authenticate
+// challenge, and with 503 when their credentials cannot be checked.
+func Middleware(authenticators *Authenticators) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logCtx := logger.GetLogContext(c)
+		credential, authenticator, challenge := authenticators.credential(c.Request)
+		if authenticator == nil {
+			reject(c, http.StatusUnauthorized, challenge, "missing credentials")
+			return
+		}
+		claims, err := authenticator.Authenticate(logCtx, credential)
+		if err != nil {
+			logger.Warn(logCtx, "Rejected request credentials", logger.ErrorField(logger.FieldError, err))
+			if errors.Is(err, ErrUnavailable) {
+				reject(c, http.StatusServiceUnavailable, "", "authentication unavailable")
+				return
+			}
+			reject(c, http.StatusUnauthorized, challenge, "invalid credentials")
+			return
+		}
+
+		ctx := logger.WithFields(WithClaims(logCtx, claims),
+			logger.String(logger.FieldSubject, claims.Subject),
+			logger.String(logger.FieldAuthMethod, claims.Method),
+		)
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.String(AttributeSubject, claims.Subject),
+			attribute.String(AttributeAuthMethod, claims.Method),
+		)
+		logger.SetLogContext(ctx, c)
+		c.Next()
+	}
+}
+
+// credential returns the credential of req with its authenticator, or a nil authenticator
+// when req carries none that is accepted, and the challenge answering a refusal.
+func (a *Authenticators) credential(req *http.Request) (value string, authenticator Authenticator, challenge string) {
+	if a.APIKey != nil {
+		if key := strings.TrimSpace(req.Header.Get(a.APIKeyHeader)); key != "" {
+			return key, a.APIKey, a.apiKeyChallenge()
+		}
+	}
+	if a.Bearer != nil {
+		if token, ok := bearerToken(req); ok {
+			return token, a.Bearer, invalidTokenChallenge
+		}
+	}
+	var challenges []string
+	if a.Bearer != nil {
+		challenges = append(challenges, bearerScheme)
+	}
+	if a.APIKey != nil {
+		challenges = append(challenges, a.apiKeyChallenge())
+	}
+	return "", nil, strings.Join(challenges, ", ")
+}
+
+func (a *Authenticators) apiKeyChallenge() string {
+	return apiKeyScheme + ` header="` + a.APIKeyHeader + `"`
+}
+
+// bearerToken returns the token of an "Authorization: Bearer <token>" header.
+func bearerToken(req *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(req.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, bearerScheme) {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// reject aborts the request with status. The reason credentials were refused is only
+// logged, so callers cannot probe the validation.
+func reject(c *gin.Context, status int, challenge, message string) {
+	if challenge != "" {
+		c.Header("WWW-Authenticate", challenge)
+	}
+	c.AbortWithStatusJSON(status, gin.H{"error": message})
+}
+
+const (
+	// AttributeSubject is the span attribute holding the subject of the request credentials.
+	AttributeSubject = "enduser.id"
+	// AttributeAuthMethod is the span attribute holding how the request authenticated.
+	AttributeAuthMethod   = "auth.method"
+	bearerScheme          = "Bearer"
+	apiKeyScheme          = "APIKey"
+	invalidTokenChallenge = bearerScheme + ` error="invalid_token"`
+)
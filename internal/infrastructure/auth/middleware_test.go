@@ -0,0 +1,117 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-service-template/internal/infrastructure/auth"
+	"go-service-template/internal/infrastructure/auth/mocks"
+	"go-service-template/internal/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAuthRouter(authenticators *auth.Authenticators, seen **auth.Claims) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(logger.LoggingMiddleware(), auth.Middleware(authenticators))
+	r.GET("/api", func(c *gin.Context) {
+		*seen = auth.FromContext(logger.GetLogContext(c))
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func bearerOnly(authenticator auth.Authenticator) *auth.Authenticators {
+	return &auth.Authenticators{Bearer: authenticator}
+}
+
+func serveWithAuthorization(r *gin.Engine, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_ValidToken_AttachesClaims(t *testing.T) {
+	authenticator := mocks.NewAuthenticator(t)
+	authenticator.EXPECT().Authenticate(mock.Anything, "good").Return(&auth.Claims{Subject: "user-1"}, nil).Once()
+	var seen *auth.Claims
+
+	w := serveWithAuthorization(newAuthRouter(bearerOnly(authenticator), &seen), "bearer good")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "user-1", seen.Subject)
+}
+
+func TestMiddleware_MissingToken_Unauthorized(t *testing.T) {
+	for _, authorization := range []string{"", "Basic dXNlcjpwYXNz", "Bearer "} {
+		var seen *auth.Claims
+		w := serveWithAuthorization(newAuthRouter(bearerOnly(mocks.NewAuthenticator(t)), &seen), authorization)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+		assert.Nil(t, seen)
+	}
+}
+
+func TestMiddleware_InvalidToken_Unauthorized(t *testing.T) {
+	authenticator := mocks.NewAuthenticator(t)
+	authenticator.EXPECT().Authenticate(mock.Anything, "bad").Return(nil, auth.ErrInvalidToken).Once()
+	var seen *auth.Claims
+
+	w := serveWithAuthorization(newAuthRouter(bearerOnly(authenticator), &seen), "Bearer bad")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Bearer error="invalid_token"`, w.Header().Get("WWW-Authenticate"))
+	assert.JSONEq(t, `{"error":"invalid credentials"}`, w.Body.String())
+	assert.Nil(t, seen)
+}
+
+func TestMiddleware_APIKey_TakesPrecedenceOverBearer(t *testing.T) {
+	bearer := mocks.NewAuthenticator(t)
+	apiKey := mocks.NewAuthenticator(t)
+	apiKey.EXPECT().Authenticate(mock.Anything, "k3y").Return(&auth.Claims{Method: auth.MethodAPIKey, Subject: "billing"}, nil).Once()
+	var seen *auth.Claims
+	r := newAuthRouter(&auth.Authenticators{Bearer: bearer, APIKey: apiKey, APIKeyHeader: "X-API-Key"}, &seen)
+	req := httptest.NewRequest(http.MethodGet, "/api", nil)
+	req.Header.Set("X-API-Key", "k3y")
+	req.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "billing", seen.Subject)
+}
+
+func TestMiddleware_NoCredentials_ChallengesEveryScheme(t *testing.T) {
+	var seen *auth.Claims
+	r := newAuthRouter(&auth.Authenticators{Bearer: mocks.NewAuthenticator(t), APIKey: mocks.NewAuthenticator(t), APIKeyHeader: "X-API-Key"}, &seen)
+
+	w := serveWithAuthorization(r, "")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Bearer, APIKey header="X-API-Key"`, w.Header().Get("WWW-Authenticate"))
+}
+
+func TestMiddleware_Unavailable_ServiceUnavailable(t *testing.T) {
+	apiKey := mocks.NewAuthenticator(t)
+	apiKey.EXPECT().Authenticate(mock.Anything, "k3y").Return(nil, auth.ErrUnavailable).Once()
+	var seen *auth.Claims
+	r := newAuthRouter(&auth.Authenticators{APIKey: apiKey, APIKeyHeader: "X-API-Key"}, &seen)
+	req := httptest.NewRequest(http.MethodGet, "/api", nil)
+	req.Header.Set("X-API-Key", "k3y")
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Nil(t, seen)
+}
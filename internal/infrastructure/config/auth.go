@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AuthConfig authenticates the API requests.
+type AuthConfig struct {
+	JWT     JWTConfig     `yaml:"jwt"`
+	APIKeys APIKeysConfig `yaml:"api_keys"`
+}
+
+// APIKeysConfig authenticates service-to-service calls by the API key sent in Header. Keys
+// are only ever stored as the hex SHA-256 of their value: in Keys, and in Redis when Redis
+// is set, where the admin API issues, rotates and revokes them.
+type APIKeysConfig struct {
+	Header string         `yaml:"header"`
+	Redis  bool           `yaml:"redis"`
+	Keys   []APIKeyConfig `yaml:"keys"`
+}
+
+// APIKeyConfig is a key accepted until ExpiresAt, or forever when it is zero. Keys rotate by
+// listing the new key under the same ID and giving the old one an ExpiresAt far enough
+// away for its callers to switch.
+type APIKeyConfig struct {
+	ID     string   `yaml:"id"`
+	Hash   string   `yaml:"hash"`
+	Scopes []string `yaml:"scopes"`
+	// Tenant is the only tenant the key may act for; the default tenant when empty.
+	Tenant    string    `yaml:"tenant"`
+	ExpiresAt time.Time `yaml:"expires_at"`
+}
+
+// Enabled reports whether requests may authenticate with an API key.
+func (a *APIKeysConfig) Enabled() bool {
+	return a.Redis || len(a.Keys) > 0
+}
+
+// Enabled reports whether API callers can be authenticated by any scheme.
+func (a *AuthConfig) Enabled() bool {
+	return a.JWT.Enabled() || a.APIKeys.Enabled()
+}
+
+func (a *APIKeysConfig) validate() error {
+	if a.Header == EmptyString {
+		return fmt.Errorf("%w: API key header is required", ErrInvalidConfig)
+	}
+	for i := range a.Keys {
+		key := &a.Keys[i]
+		if !ValidAPIKeyID(key.ID) {
+			return fmt.Errorf("%w: invalid API key ID %q", ErrInvalidConfig, key.ID)
+		}
+		if !ValidAPIKeyHash(key.Hash) {
+			return fmt.Errorf("%w: API key %q hash must be a lowercase hex SHA-256", ErrInvalidConfig, key.ID)
+		}
+		if !ValidScopes(key.Scopes) {
+			return fmt.Errorf("%w: API key %q needs valid scopes", ErrInvalidConfig, key.ID)
+		}
+		if key.Tenant != EmptyString && !ValidTenantID(key.Tenant) {
+			return fmt.Errorf("%w: API key %q has an invalid tenant %q", ErrInvalidConfig, key.ID, key.Tenant)
+		}
+	}
+	return nil
+}
+
+// JWTConfig validates the bearer tokens of API requests: HS256 tokens against Secret, and
+// RS256 and ES256 tokens against the keys of the JWKS read from JWKSFile or fetched from
+// JWKSURL. The JWKS is reloaded every JWKSRefresh, and sooner when a token names a key it
+// does not hold, so rotated keys are picked up. Issuer and Audience are checked when set.
+type JWTConfig struct {
+	Secret      string        `yaml:"secret"`
+	JWKSFile    string        `yaml:"jwks_file"`
+	JWKSURL     string        `yaml:"jwks_url"`
+	JWKSRefresh time.Duration `yaml:"jwks_refresh"`
+	Issuer      string        `yaml:"issuer"`
+	Audience    string        `yaml:"audience"`
+	// Leeway tolerates clock skew when checking the expiry and not-before times.
+	Leeway time.Duration `yaml:"leeway"`
+}
+
+// Enabled reports whether tokens can be validated, which makes them required.
+func (j *JWTConfig) Enabled() bool {
+	return j.Secret != EmptyString || j.JWKS() != EmptyString
+}
+
+// JWKS returns the file or URL the keys are read from, or "" without one.
+func (j *JWTConfig) JWKS() string {
+	if j.JWKSFile != EmptyString {
+		return j.JWKSFile
+	}
+	return j.JWKSURL
+}
+
+func (j *JWTConfig) validate() error {
+	if j.Secret != EmptyString && len(j.Secret) < minJWTSecretLength {
+		return fmt.Errorf("%w: JWT secret shorter than %d bytes", ErrInvalidConfig, minJWTSecretLength)
+	}
+	if j.JWKSFile != EmptyString && j.JWKSURL != EmptyString {
+		return fmt.Errorf("%w: JWKS file and URL are exclusive", ErrInvalidConfig)
+	}
+	if j.JWKSURL != EmptyString && !validHTTPURL(j.JWKSURL) {
+		return fmt.Errorf("%w: invalid JWKS URL %q", ErrInvalidConfig, j.JWKSURL)
+	}
+	if j.JWKSRefresh <= 0 || j.Leeway < 0 {
+		return fmt.Errorf("%w: JWKS refresh must be positive and JWT leeway not negative", ErrInvalidConfig)
+	}
+	return nil
+}
+
+func validHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != EmptyString
+}
+
+// AdminConfig protects the /admin endpoints. They are disabled when Token is empty.
+type AdminConfig struct {
+	Token string `yaml:"token"`
+}
+
+// ValidAPIKeyID reports whether id may name an API key, with the rules of tenant IDs.
+func ValidAPIKeyID(id string) bool {
+	return ValidTenantID(id)
+}
+
+// ValidAPIKeyHash reports whether hash is the lowercase hex SHA-256 of a key.
+func ValidAPIKeyHash(hash string) bool {
+	return len(hash) == sha256HexLength && strings.IndexFunc(hash, invalidHexRune) < 0
+}
+
+// ValidScopes reports whether scopes is not empty and only holds "<resource>:<action>"
+// pairs of lowercase words, such as "limit:check".
+func ValidScopes(scopes []string) bool {
+	return len(scopes) > 0 && !slices.ContainsFunc(scopes, invalidScope)
+}
+
+func invalidScope(scope string) bool {
+	resource, action, ok := strings.Cut(scope, ":")
+	return !ok || !validScopeWord(resource) || !validScopeWord(action)
+}
+
+func validScopeWord(word string) bool {
+	return word != EmptyString && strings.IndexFunc(word, invalidScopeRune) < 0
+}
+
+func invalidScopeRune(r rune) bool {
+	return (r < 'a' || r > 'z') && r != '_'
+}
+
+func invalidHexRune(r rune) bool {
+	return (r < '0' || r > '9') && (r < 'a' || r > 'f')
+}
+
+func (c *Config) applyAuthEnv() {
+	c.Auth.JWT.Secret = getEnv(EnvJWTSecret, c.Auth.JWT.Secret)
+	c.Auth.JWT.JWKSFile = getEnv(EnvJWTJWKSFile, c.Auth.JWT.JWKSFile)
+	c.Auth.JWT.JWKSURL = getEnv(EnvJWTJWKSURL, c.Auth.JWT.JWKSURL)
+	c.Auth.JWT.JWKSRefresh = getEnvAsDuration(EnvJWTJWKSRefresh, c.Auth.JWT.JWKSRefresh)
+	c.Auth.JWT.Issuer = getEnv(EnvJWTIssuer, c.Auth.JWT.Issuer)
+	c.Auth.JWT.Audience = getEnv(EnvJWTAudience, c.Auth.JWT.Audience)
+	c.Auth.JWT.Leeway = getEnvAsDuration(EnvJWTLeeway, c.Auth.JWT.Leeway)
+	c.Auth.APIKeys.Header = getEnv(EnvAPIKeyHeader, c.Auth.APIKeys.Header)
+	c.Auth.APIKeys.Redis = getEnvAsBool(EnvAPIKeysRedis, c.Auth.APIKeys.Redis)
+}
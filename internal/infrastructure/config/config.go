@@ -1,46 +1,244 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Server ServerConfig
-	Redis  RedisConfig
-	Env    string
+	Server  ServerConfig  `yaml:"server"`
+	Redis   RedisConfig   `yaml:"redis"`
+	UserAPI UserAPIConfig `yaml:"user_api"`
+	Log     LogConfig     `yaml:"log"`
+	CORS    CORSConfig    `yaml:"cors"`
+	Limit   LimitConfig   `yaml:"limit"`
+	Tracing TracingConfig `yaml:"tracing"`
+	Admin   AdminConfig   `yaml:"admin"`
+	Health  HealthConfig  `yaml:"health"`
+	Startup StartupConfig `yaml:"startup"`
+	Tenancy TenancyConfig `yaml:"tenancy"`
+	Auth    AuthConfig    `yaml:"auth"`
+	Env     string        `yaml:"env"`
+	Profile Profile       `yaml:"-"`
+	File    string        `yaml:"-"`
 }
 
 type ServerConfig struct {
-	Host         string
-	Port         string
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
-	AppName      string
-	OTLPEndpoint string
+	Host         string        `yaml:"host"`
+	Port         string        `yaml:"port"`
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	AppName      string        `yaml:"app_name"`
+	OTLPEndpoint string        `yaml:"otlp_endpoint"`
+}
+
+// UserAPIConfig locates the upstream user API. Without a BaseURL the web API repository
+// makes no calls.
+type UserAPIConfig struct {
+	BaseURL string        `yaml:"base_url"`
+	Timeout time.Duration `yaml:"timeout"`
 }
 
-type RedisConfig struct {
-	Host string
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowed_origins"`
 }
 
+// NewConfig builds the configuration from the defaults and the environment without
+// validating it; an unknown PROFILE falls back to the local profile. Use Load to run the
+// service.
 func NewConfig() *Config {
+	cfg := defaultConfig()
+	profile, err := resolveProfile()
+	if err != nil {
+		profile = ProfileLocal
+	}
+	cfg.applyProfile(profile)
+	cfg.applyEnv()
+	return cfg
+}
+
+// Load builds the configuration from the base defaults, the profile defaults, the
+// profile overlay file (or CONFIG_FILE) and the environment, in increasing order of
+// precedence, and validates the result.
+func Load() (*Config, error) {
+	profile, err := resolveProfile()
+	if err != nil {
+		return nil, err
+	}
+	cfg := defaultConfig()
+	cfg.applyProfile(profile)
+	cfg.File = getEnv(EnvConfigFile, profileFile(cfg.Profile))
+	if cfg.File != EmptyString {
+		if err := cfg.applyFile(cfg.File); err != nil {
+			return nil, err
+		}
+	}
+	cfg.applyEnv()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate reports the first setting that would leave the service in a broken state.
+func (c *Config) Validate() error {
+	if err := c.Log.validate(); err != nil {
+		return err
+	}
+	if err := c.Limit.validate(); err != nil {
+		return err
+	}
+	if err := c.validateTenancy(); err != nil {
+		return err
+	}
+	if err := c.Tracing.validate(); err != nil {
+		return err
+	}
+	if err := c.Redis.validate(); err != nil {
+		return err
+	}
+	if err := c.Auth.JWT.validate(); err != nil {
+		return err
+	}
+	if err := c.Auth.APIKeys.validate(); err != nil {
+		return err
+	}
+	if err := c.Startup.validate(); err != nil {
+		return err
+	}
+	return c.validateProfile()
+}
+
+// resolveProfile returns the profile named by PROFILE, which must be a known profile, or
+// the one mapped from the ENV label.
+func resolveProfile() (Profile, error) {
+	if name := os.Getenv(EnvProfile); name != EmptyString {
+		return ParseProfile(name)
+	}
+	return ProfileFor(getEnv(EnvEnvironment, DefaultEnv)), nil
+}
+
+func defaultConfig() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Host:         getEnv(EnvHost, DefaultHost),
-			Port:         getEnv(EnvPort, DefaultPort),
-			ReadTimeout:  getEnvAsDuration(EnvReadTimeout, DefaultReadTimeout),
-			WriteTimeout: getEnvAsDuration(EnvWriteTimeout, DefaultWriteTimeout),
-			AppName:      getEnv(EnvAppName, DefaultAppName),
-			OTLPEndpoint: getEnv(EnvOLTPEndpoint, DefaultOTLPEndpoint),
+			Host:         DefaultHost,
+			Port:         DefaultPort,
+			ReadTimeout:  DefaultReadTimeout,
+			WriteTimeout: DefaultWriteTimeout,
+			AppName:      DefaultAppName,
+			OTLPEndpoint: DefaultOTLPEndpoint,
 		},
 		Redis: RedisConfig{
-			Host: getEnv(EnvRedisHost, DefaultRedisHost),
+			Mode:                 RedisModeStandalone,
+			Host:                 DefaultRedisHost,
+			DialTimeout:          DefaultRedisDialTimeout,
+			ReadTimeout:          DefaultRedisReadTimeout,
+			WriteTimeout:         DefaultRedisWriteTimeout,
+			PoolSize:             DefaultRedisPoolSize,
+			MaxRetries:           DefaultRedisMaxRetries,
+			SlowCommandThreshold: DefaultRedisSlowCommandThreshold,
+		},
+		Log: defaultLogConfig(),
+		UserAPI: UserAPIConfig{
+			Timeout: DefaultUserAPITimeout,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{WildcardOrigin},
 		},
-		Env: getEnv(EnvEnvironment, DefaultEnv),
+		Limit: LimitConfig{
+			DefaultPolicy: DefaultLimitPolicy,
+			Policies: map[string]LimitPolicy{
+				DefaultLimitPolicy: {Limit: DefaultLimit, Window: DefaultLimitWindow},
+			},
+		},
+		Tracing: TracingConfig{
+			Sampler:     SamplerAlways,
+			SampleRatio: DefaultSampleRatio,
+			Exporter:    TraceExporterOTLPGRPC,
+		},
+		Health: HealthConfig{
+			Timeout:  DefaultHealthCheckTimeout,
+			CacheTTL: DefaultHealthCacheTTL,
+		},
+		Startup: StartupConfig{
+			Timeout:        DefaultStartupTimeout,
+			InitialBackoff: DefaultStartupInitialBackoff,
+			MaxBackoff:     DefaultStartupMaxBackoff,
+		},
+		Tenancy: TenancyConfig{
+			Header:  DefaultTenantHeader,
+			Default: DefaultTenant,
+		},
+		Auth: AuthConfig{
+			JWT: JWTConfig{
+				JWKSRefresh: DefaultJWKSRefresh,
+				Leeway:      DefaultJWTLeeway,
+			},
+			APIKeys: APIKeysConfig{
+				Header: DefaultAPIKeyHeader,
+			},
+		},
+		Env: DefaultEnv,
 	}
 }
 
+func (c *Config) applyFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("%w: parse config file %s: %w", ErrInvalidConfig, path, err)
+	}
+	return nil
+}
+
+func (c *Config) applyEnv() {
+	c.Server.Host = getEnv(EnvHost, c.Server.Host)
+	c.Server.Port = getEnv(EnvPort, c.Server.Port)
+	c.Server.ReadTimeout = getEnvAsDuration(EnvReadTimeout, c.Server.ReadTimeout)
+	c.Server.WriteTimeout = getEnvAsDuration(EnvWriteTimeout, c.Server.WriteTimeout)
+	c.Server.AppName = getEnv(EnvAppName, c.Server.AppName)
+	c.Server.OTLPEndpoint = getEnv(EnvOLTPEndpoint, c.Server.OTLPEndpoint)
+	c.applyRedisEnv()
+	c.UserAPI.BaseURL = getEnv(EnvUserAPIURL, c.UserAPI.BaseURL)
+	c.UserAPI.Timeout = getEnvAsDuration(EnvUserAPITimeout, c.UserAPI.Timeout)
+	c.Health.Timeout = getEnvAsDuration(EnvHealthCheckTimeout, c.Health.Timeout)
+	c.Health.CacheTTL = getEnvAsDuration(EnvHealthCacheTTL, c.Health.CacheTTL)
+	c.Startup.Timeout = getEnvAsDuration(EnvStartupTimeout, c.Startup.Timeout)
+	c.Startup.InitialBackoff = getEnvAsDuration(EnvStartupInitialBackoff, c.Startup.InitialBackoff)
+	c.Startup.MaxBackoff = getEnvAsDuration(EnvStartupMaxBackoff, c.Startup.MaxBackoff)
+	c.Log.Level = getEnv(EnvLogLevel, c.Log.Level)
+	c.Log.Sampling.Initial = getEnvAsInt(EnvLogSamplingInitial, c.Log.Sampling.Initial)
+	c.Log.Sampling.Thereafter = getEnvAsInt(EnvLogSamplingThereafter, c.Log.Sampling.Thereafter)
+	c.Log.Sampling.DedupWindow = getEnvAsDuration(EnvLogDedupWindow, c.Log.Sampling.DedupWindow)
+	c.Log.Redaction.Keys = getEnvAsSlice(EnvLogRedactKeys, c.Log.Redaction.Keys)
+	c.Log.Redaction.Mode = getEnv(EnvLogRedactMode, c.Log.Redaction.Mode)
+	c.applySinkEnv()
+	c.Log.Body.Routes = getEnvAsSlice(EnvLogBodyRoutes, c.Log.Body.Routes)
+	c.Log.Body.MaxBytes = getEnvAsInt(EnvLogBodyMaxBytes, c.Log.Body.MaxBytes)
+	c.CORS.AllowedOrigins = getEnvAsSlice(EnvCORSAllowedOrigins, c.CORS.AllowedOrigins)
+	c.Tracing.Sampler = getEnv(EnvTraceSampler, c.Tracing.Sampler)
+	c.Tracing.SampleRatio = getEnvAsFloat(EnvTraceSampleRatio, c.Tracing.SampleRatio)
+	c.Tracing.Exporter = getEnv(EnvTraceExporter, c.Tracing.Exporter)
+	c.Tracing.TLS = getEnvAsBool(EnvTraceOTLPTLS, c.Tracing.TLS)
+	c.Tracing.Headers = getEnvAsMap(EnvTraceOTLPHeaders, c.Tracing.Headers)
+	c.Admin.Token = getEnv(EnvAdminToken, c.Admin.Token)
+	c.Tenancy.Header = getEnv(EnvTenantHeader, c.Tenancy.Header)
+	c.Tenancy.Hosts = getEnvAsMap(EnvTenantHosts, c.Tenancy.Hosts)
+	c.Tenancy.Default = getEnvAllowEmpty(EnvTenantDefault, c.Tenancy.Default)
+	c.Tenancy.Strict = getEnvAsBool(EnvTenantStrict, c.Tenancy.Strict)
+	c.applyAuthEnv()
+	c.Env = getEnv(EnvEnvironment, c.Env)
+}
+
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != EmptyString {
 		return value
@@ -48,6 +246,14 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// getEnvAllowEmpty is getEnv for settings that an empty value disables.
+func getEnvAllowEmpty(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return strings.TrimSpace(value)
+	}
+	return fallback
+}
+
 func getEnvAsDuration(key string, fallback time.Duration) time.Duration {
 	if value := os.Getenv(key); value != EmptyString {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -57,28 +263,183 @@ func getEnvAsDuration(key string, fallback time.Duration) time.Duration {
 	return fallback
 }
 
+func getEnvAsInt(key string, fallback int) int {
+	if value := os.Getenv(key); value != EmptyString {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return fallback
+}
+
+func getEnvAsFloat(key string, fallback float64) float64 {
+	if value := os.Getenv(key); value != EmptyString {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
+
+func getEnvAsBool(key string, fallback bool) bool {
+	if value := os.Getenv(key); value != EmptyString {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+// getEnvAsMap parses a comma-separated list of key=value pairs.
+func getEnvAsMap(key string, fallback map[string]string) map[string]string {
+	items := getEnvAsSlice(key, nil)
+	if len(items) == 0 {
+		return fallback
+	}
+	values := make(map[string]string, len(items))
+	for _, item := range items {
+		if k, v, ok := strings.Cut(item, "="); ok && strings.TrimSpace(k) != EmptyString {
+			values[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return values
+}
+
+func getEnvAsSlice(key string, fallback []string) []string {
+	value := os.Getenv(key)
+	if value == EmptyString {
+		return fallback
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != EmptyString {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+var ErrInvalidConfig = errors.New("invalid configuration")
+
 const (
-	EmptyString = ""
+	EmptyString    = ""
+	WildcardOrigin = "*"
 )
 
 const (
-	EnvHost         = "HOST"
-	EnvPort         = "PORT"
-	EnvReadTimeout  = "READ_TIMEOUT"
-	EnvWriteTimeout = "WRITE_TIMEOUT"
-	EnvRedisHost    = "REDIS_HOST"
-	EnvEnvironment  = "ENV"
-	EnvAppName      = "APP_NAME"
-	EnvOLTPEndpoint = "OTLP_ENDPOINT"
+	EnvHost                      = "HOST"
+	EnvPort                      = "PORT"
+	EnvReadTimeout               = "READ_TIMEOUT"
+	EnvWriteTimeout              = "WRITE_TIMEOUT"
+	EnvRedisHost                 = "REDIS_HOST"
+	EnvRedisMode                 = "REDIS_MODE"
+	EnvRedisAddrs                = "REDIS_ADDRS"
+	EnvRedisMasterName           = "REDIS_MASTER_NAME"
+	EnvRedisSentinelUsername     = "REDIS_SENTINEL_USERNAME"
+	EnvRedisSentinelPassword     = "REDIS_SENTINEL_PASSWORD"
+	EnvUserAPIURL                = "USER_API_URL"
+	EnvUserAPITimeout            = "USER_API_TIMEOUT"
+	EnvRedisUsername             = "REDIS_USERNAME"
+	EnvRedisPassword             = "REDIS_PASSWORD"
+	EnvRedisDB                   = "REDIS_DB"
+	EnvRedisTLS                  = "REDIS_TLS"
+	EnvRedisTLSCAFile            = "REDIS_TLS_CA_FILE"
+	EnvRedisTLSCertFile          = "REDIS_TLS_CERT_FILE"
+	EnvRedisTLSKeyFile           = "REDIS_TLS_KEY_FILE"
+	EnvRedisTLSServerName        = "REDIS_TLS_SERVER_NAME"
+	EnvRedisDialTimeout          = "REDIS_DIAL_TIMEOUT"
+	EnvRedisReadTimeout          = "REDIS_READ_TIMEOUT"
+	EnvRedisWriteTimeout         = "REDIS_WRITE_TIMEOUT"
+	EnvRedisPoolSize             = "REDIS_POOL_SIZE"
+	EnvRedisMinIdleConns         = "REDIS_MIN_IDLE_CONNS"
+	EnvRedisMaxRetries           = "REDIS_MAX_RETRIES"
+	EnvRedisSlowCommandThreshold = "REDIS_SLOW_COMMAND_THRESHOLD"
+	EnvHealthCheckTimeout        = "HEALTH_CHECK_TIMEOUT"
+	EnvHealthCacheTTL            = "HEALTH_CACHE_TTL"
+	EnvStartupTimeout            = "STARTUP_TIMEOUT"
+	EnvStartupInitialBackoff     = "STARTUP_INITIAL_BACKOFF"
+	EnvStartupMaxBackoff         = "STARTUP_MAX_BACKOFF"
+	EnvEnvironment               = "ENV"
+	EnvAppName                   = "APP_NAME"
+	EnvOLTPEndpoint              = "OTLP_ENDPOINT"
+	EnvLogLevel                  = "LOG_LEVEL"
+	EnvLogSamplingInitial        = "LOG_SAMPLING_INITIAL"
+	EnvLogSamplingThereafter     = "LOG_SAMPLING_THEREAFTER"
+	EnvLogDedupWindow            = "LOG_DEDUP_WINDOW" //nolint:gosec // Variable name, not a credential ("upWindow" matches G101).
+	EnvLogRedactKeys             = "LOG_REDACT_KEYS"
+	EnvLogRedactMode             = "LOG_REDACT_MODE"
+	EnvLogSinks                  = "LOG_SINKS"
+	EnvLogFilePath               = "LOG_FILE_PATH"
+	EnvLogBodyRoutes             = "LOG_BODY_ROUTES"
+	EnvLogBodyMaxBytes           = "LOG_BODY_MAX_BYTES"
+	EnvCORSAllowedOrigins        = "CORS_ALLOWED_ORIGINS"
+	EnvConfigFile                = "CONFIG_FILE"
+	EnvConfigDir                 = "CONFIG_DIR"
+	EnvProfile                   = "PROFILE"
+	EnvTraceSampler              = "TRACE_SAMPLER"
+	EnvTraceSampleRatio          = "TRACE_SAMPLE_RATIO"
+	EnvTraceExporter             = "TRACE_EXPORTER"
+	EnvTraceOTLPTLS              = "TRACE_OTLP_TLS"
+	EnvTraceOTLPHeaders          = "TRACE_OTLP_HEADERS"
+	EnvAdminToken                = "ADMIN_TOKEN"
+	EnvTenantHeader              = "TENANT_HEADER"
+	EnvTenantHosts               = "TENANT_HOSTS"
+	EnvTenantDefault             = "TENANT_DEFAULT"
+	EnvTenantStrict              = "TENANT_STRICT"
+	EnvJWTSecret                 = "JWT_SECRET"
+	EnvJWTJWKSFile               = "JWT_JWKS_FILE"
+	EnvJWTJWKSURL                = "JWT_JWKS_URL"
+	EnvJWTJWKSRefresh            = "JWT_JWKS_REFRESH"
+	EnvJWTIssuer                 = "JWT_ISSUER"
+	EnvJWTAudience               = "JWT_AUDIENCE"
+	EnvJWTLeeway                 = "JWT_LEEWAY"
+	EnvAPIKeyHeader              = "API_KEY_HEADER" //nolint:gosec // Header and variable names, not credentials.
+	EnvAPIKeysRedis              = "API_KEYS_REDIS" //nolint:gosec // Header and variable names, not credentials.
 )
 
 const (
-	DefaultHost         = "0.0.0.0"
-	DefaultPort         = "8080"
-	DefaultReadTimeout  = 60 * time.Second
-	DefaultWriteTimeout = 60 * time.Second
-	DefaultRedisHost    = "localhost"
-	DefaultAppName      = "go-service-template"
-	DefaultOTLPEndpoint = "localhost:4317"
-	DefaultEnv          = "local"
+	DefaultHost                      = "0.0.0.0"
+	DefaultPort                      = "8080"
+	DefaultReadTimeout               = 60 * time.Second
+	DefaultWriteTimeout              = 60 * time.Second
+	DefaultRedisHost                 = "localhost"
+	DefaultUserAPITimeout            = 5 * time.Second
+	DefaultRedisDialTimeout          = 5 * time.Second
+	DefaultRedisReadTimeout          = 3 * time.Second
+	DefaultRedisWriteTimeout         = 3 * time.Second
+	DefaultRedisPoolSize             = 10
+	DefaultRedisMaxRetries           = 3
+	DefaultRedisSlowCommandThreshold = 100 * time.Millisecond
+	DefaultHealthCheckTimeout        = 2 * time.Second
+	DefaultHealthCacheTTL            = 5 * time.Second
+	DefaultStartupTimeout            = time.Minute
+	DefaultStartupInitialBackoff     = 200 * time.Millisecond
+	DefaultStartupMaxBackoff         = 5 * time.Second
+	DefaultAppName                   = "go-service-template"
+	DefaultOTLPEndpoint              = "localhost:4317"
+	DefaultEnv                       = "local"
+	DefaultLogLevel                  = "info"
+	DefaultLimitPolicy               = "default"
+	DefaultTenantHeader              = "X-Tenant-ID"
+	DefaultTenant                    = "default"
+	maxTenantIDLength                = 64
+	DefaultJWKSRefresh               = 5 * time.Minute
+	DefaultJWTLeeway                 = 30 * time.Second
+	minJWTSecretLength               = 32
+	DefaultAPIKeyHeader              = "X-API-Key" //nolint:gosec // Header and variable names, not credentials.
+	sha256HexLength                  = 64
+	DefaultLimit                     = 100
+	DefaultLimitWindow               = time.Minute
+	DefaultSampleRatio               = 1.0
+	DefaultConfigDir                 = "configs"
+	DefaultLogSamplingInitial        = 100
+	DefaultLogSamplingThereafter     = 100
+	DefaultLogBodyMaxBytes           = 4 << 10
+	MaxLogBodyBytes                  = 64 << 10
+	DefaultLogDedupWindow            = 10 * time.Second
+	DefaultHealthRoute               = "/health"
+	DefaultLivenessRoute             = "/health/live"
+	DefaultReadinessRoute            = "/health/ready"
+	DefaultStartupRoute              = "/health/startup"
+	DefaultHealthLogSampling         = 100
 )
@@ -2,10 +2,13 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewConfig_DefaultServerHost(t *testing.T) {
@@ -152,3 +155,355 @@ func TestProvider_GetAppName_Value(t *testing.T) {
 	c := NewConfig()
 	assert.Equal(t, "a", c.GetAppName())
 }
+
+func TestNewConfig_DefaultLimitPolicy(t *testing.T) {
+	os.Clearenv()
+	c := NewConfig()
+	name, policy, ok := c.GetLimitConfig().Policy("")
+	assert.True(t, ok)
+	assert.Equal(t, DefaultLimitPolicy, name)
+	assert.Equal(t, DefaultLimit, policy.Limit)
+}
+
+func TestNewConfig_CORSAllowedOriginsFromEnv(t *testing.T) {
+	t.Setenv(EnvCORSAllowedOrigins, "https://a.example, https://b.example")
+	c := NewConfig()
+	assert.Equal(t, []string{"https://a.example", "https://b.example"}, c.GetCORSAllowedOrigins())
+}
+
+func TestNewConfig_TenancyFromEnv(t *testing.T) {
+	t.Setenv(EnvTenantHeader, "X-Org")
+	t.Setenv(EnvTenantHosts, "acme.example.com=acme")
+	t.Setenv(EnvTenantDefault, "")
+	t.Setenv(EnvTenantStrict, "true")
+
+	c := NewConfig()
+
+	assert.Equal(t, TenancyConfig{Header: "X-Org", Hosts: map[string]string{"acme.example.com": "acme"}, Strict: true}, c.GetTenancyConfig())
+}
+
+func TestNewConfig_JWTFromEnv(t *testing.T) {
+	t.Setenv(EnvJWTJWKSURL, "https://issuer.example/.well-known/jwks.json")
+	t.Setenv(EnvJWTJWKSRefresh, "1m")
+	t.Setenv(EnvJWTIssuer, "https://issuer.example")
+	t.Setenv(EnvJWTAudience, "api")
+	t.Setenv(EnvJWTLeeway, "5s")
+
+	jwt := NewConfig().GetAuthConfig().JWT
+
+	assert.True(t, jwt.Enabled())
+	assert.Equal(t, JWTConfig{
+		JWKSURL:     "https://issuer.example/.well-known/jwks.json",
+		JWKSRefresh: time.Minute,
+		Issuer:      "https://issuer.example",
+		Audience:    "api",
+		Leeway:      5 * time.Second,
+	}, jwt)
+}
+
+func TestJWTConfig_Enabled_DefaultsToDisabled(t *testing.T) {
+	jwt := defaultConfig().Auth.JWT
+	assert.False(t, jwt.Enabled())
+}
+
+func TestNewConfig_APIKeysFromEnv(t *testing.T) {
+	t.Setenv(EnvAPIKeyHeader, "X-Service-Key")
+	t.Setenv(EnvAPIKeysRedis, "true")
+
+	keys := NewConfig().GetAuthConfig().APIKeys
+
+	assert.Equal(t, "X-Service-Key", keys.Header)
+	assert.True(t, keys.Redis)
+	assert.True(t, keys.Enabled())
+	assert.False(t, defaultConfig().Auth.APIKeys.Enabled())
+}
+
+func TestValidScopes(t *testing.T) {
+	assert.True(t, ValidScopes([]string{"limit:check", "user_admin:write"}))
+	assert.False(t, ValidScopes(nil))
+	assert.False(t, ValidScopes([]string{"limit"}))
+	assert.False(t, ValidScopes([]string{"Limit:check"}))
+	assert.False(t, ValidScopes([]string{"limit:check:all"}))
+}
+
+func TestValidAPIKeyHash(t *testing.T) {
+	assert.True(t, ValidAPIKeyHash(strings.Repeat("a1", 32)))
+	assert.False(t, ValidAPIKeyHash(strings.Repeat("A1", 32)))
+	assert.False(t, ValidAPIKeyHash("abc"))
+}
+
+func TestProvider_GetTenantLimitConfig_MergesTenantPolicies(t *testing.T) {
+	c := defaultConfig()
+	c.Limit.Policies["burst"] = LimitPolicy{Limit: 5, Window: time.Second}
+	c.Tenancy.Tenants = map[string]TenantConfig{"acme": {Limit: LimitConfig{
+		DefaultPolicy: "burst",
+		Policies:      map[string]LimitPolicy{"burst": {Limit: 50, Window: time.Second}},
+	}}}
+
+	name, policy, ok := c.GetTenantLimitConfig("acme").Policy("")
+	require.True(t, ok)
+	assert.Equal(t, "burst", name)
+	assert.Equal(t, 50, policy.Limit)
+	_, _, ok = c.GetTenantLimitConfig("acme").Policy(DefaultLimitPolicy)
+	assert.True(t, ok, "global policies stay available")
+	assert.Equal(t, c.GetLimitConfig(), c.GetTenantLimitConfig("globex"))
+	assert.Equal(t, 5, c.Limit.Policies["burst"].Limit, "the global policies are not modified")
+}
+
+func TestTenancyConfig_Known(t *testing.T) {
+	tenancy := TenancyConfig{Default: DefaultTenant, Tenants: map[string]TenantConfig{"acme": {}}}
+	assert.True(t, tenancy.Known("globex"), "any tenant is known outside strict mode")
+
+	tenancy.Strict = true
+	assert.True(t, tenancy.Known("acme"))
+	assert.True(t, tenancy.Known(DefaultTenant))
+	assert.False(t, tenancy.Known("globex"))
+}
+
+func TestValidTenantID(t *testing.T) {
+	assert.True(t, ValidTenantID("acme_eu-1"))
+	assert.False(t, ValidTenantID(""))
+	assert.False(t, ValidTenantID("acme:eu"))
+	assert.False(t, ValidTenantID(strings.Repeat("a", maxTenantIDLength+1)))
+}
+
+func TestLoad_FileOverlay_EnvTakesPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "server:\n  port: \"9000\"\n  app_name: from-file\nlimit:\n  policies:\n    burst:\n      limit: 5\n      window: 1s\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	t.Setenv(EnvConfigFile, path)
+	t.Setenv(EnvAppName, "from-env")
+
+	c, err := Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "9000", c.GetServerPort())
+	assert.Equal(t, "from-env", c.GetAppName())
+	_, burst, ok := c.GetLimitConfig().Policy("burst")
+	assert.True(t, ok)
+	assert.Equal(t, LimitPolicy{Limit: 5, Window: time.Second}, burst)
+	_, _, ok = c.GetLimitConfig().Policy(DefaultLimitPolicy)
+	assert.True(t, ok)
+}
+
+func TestLoad_MissingFile_ReturnsError(t *testing.T) {
+	t.Setenv(EnvConfigFile, filepath.Join(t.TempDir(), "missing.yaml"))
+	_, err := Load()
+	assert.Error(t, err)
+}
+
+func TestValidate_RejectsInvalidSettings(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(c *Config)
+	}{
+		{name: "UnknownLogLevel", mutate: func(c *Config) { c.Log.Level = "loud" }},
+		{name: "ZeroLimit", mutate: func(c *Config) { c.Limit.Policies["bad"] = LimitPolicy{Window: time.Second} }},
+		{name: "ZeroWindow", mutate: func(c *Config) { c.Limit.Policies["bad"] = LimitPolicy{Limit: 1} }},
+		{name: "MissingDefaultPolicy", mutate: func(c *Config) { c.Limit.DefaultPolicy = "missing" }},
+		{name: "NegativeLogSampling", mutate: func(c *Config) { c.Log.Sampling.Initial = -1 }},
+		{name: "UnknownRedactionMode", mutate: func(c *Config) { c.Log.Redaction.Mode = "rot13" }},
+		{name: "NoLogSinks", mutate: func(c *Config) { c.Log.Sinks = nil }},
+		{name: "UnknownLogSink", mutate: func(c *Config) { c.Log.Sinks = []LogSinkConfig{{Type: "syslog"}} }},
+		{name: "FileSinkWithoutPath", mutate: func(c *Config) { c.Log.Sinks = []LogSinkConfig{{Type: LogSinkFile}} }},
+		{name: "UnknownSinkLevel", mutate: func(c *Config) { c.Log.Sinks = []LogSinkConfig{{Type: LogSinkJSON, Level: "loud"}} }},
+		{name: "UnknownTraceExporter", mutate: func(c *Config) { c.Tracing.Exporter = "zipkin" }},
+		{name: "ZeroBodyCaptureLimit", mutate: func(c *Config) { c.Log.Body.MaxBytes = 0 }},
+		{name: "HugeBodyCaptureLimit", mutate: func(c *Config) { c.Log.Body.MaxBytes = MaxLogBodyBytes + 1 }},
+		{name: "ZeroRouteLogSampling", mutate: func(c *Config) { c.Log.Sampling.Routes["/health"] = 0 }},
+		{name: "NoRedisHost", mutate: func(c *Config) { c.Redis.Host = "" }},
+		{name: "NegativeRedisDB", mutate: func(c *Config) { c.Redis.DB = -1 }},
+		{name: "NegativeRedisTimeout", mutate: func(c *Config) { c.Redis.ReadTimeout = -time.Second }},
+		{name: "NegativeRedisSlowThreshold", mutate: func(c *Config) { c.Redis.SlowCommandThreshold = -time.Millisecond }},
+		{name: "ZeroRedisPoolSize", mutate: func(c *Config) { c.Redis.PoolSize = 0 }},
+		{name: "RedisMinIdleAbovePool", mutate: func(c *Config) { c.Redis.MinIdleConns = c.Redis.PoolSize + 1 }},
+		{name: "RedisMaxRetriesBelowDisabled", mutate: func(c *Config) { c.Redis.MaxRetries = -2 }},
+		{name: "RedisCertWithoutKey", mutate: func(c *Config) { c.Redis.TLS = RedisTLSConfig{Enabled: true, CertFile: "client.pem"} }},
+		{name: "RedisTLSFilesWithoutTLS", mutate: func(c *Config) { c.Redis.TLS = RedisTLSConfig{CAFile: "ca.pem"} }},
+		{name: "UnknownRedisMode", mutate: func(c *Config) { c.Redis.Mode = "replicated" }},
+		{name: "RedisSentinelWithoutMaster", mutate: func(c *Config) { c.Redis.Mode = RedisModeSentinel }},
+		{name: "RedisClusterWithDB", mutate: func(c *Config) { c.Redis.Mode = RedisModeCluster; c.Redis.DB = 1 }},
+		{name: "NoTenantHeader", mutate: func(c *Config) { c.Tenancy.Header = "" }},
+		{name: "InvalidDefaultTenant", mutate: func(c *Config) { c.Tenancy.Default = "a b" }},
+		{name: "InvalidHostTenant", mutate: func(c *Config) { c.Tenancy.Hosts = map[string]string{"a.example": "a:b"} }},
+		{name: "InvalidTenantID", mutate: func(c *Config) { c.Tenancy.Tenants = map[string]TenantConfig{"a/b": {}} }},
+		{name: "TenantMissingDefaultPolicy", mutate: func(c *Config) {
+			c.Tenancy.Tenants = map[string]TenantConfig{"acme": {Limit: LimitConfig{DefaultPolicy: "missing"}}}
+		}},
+		{name: "ShortJWTSecret", mutate: func(c *Config) { c.Auth.JWT.Secret = "short" }},
+		{name: "JWKSFileAndURL", mutate: func(c *Config) { c.Auth.JWT.JWKSFile = "jwks.json"; c.Auth.JWT.JWKSURL = "https://a.example/jwks" }},
+		{name: "InvalidJWKSURL", mutate: func(c *Config) { c.Auth.JWT.JWKSURL = "file:///etc/jwks.json" }},
+		{name: "ZeroJWKSRefresh", mutate: func(c *Config) { c.Auth.JWT.JWKSRefresh = 0 }},
+		{name: "NegativeJWTLeeway", mutate: func(c *Config) { c.Auth.JWT.Leeway = -time.Second }},
+		{name: "NoAPIKeyHeader", mutate: func(c *Config) { c.Auth.APIKeys.Header = "" }},
+		{name: "InvalidAPIKeyID", mutate: func(c *Config) {
+			c.Auth.APIKeys.Keys = []APIKeyConfig{{ID: "a b", Hash: strings.Repeat("a", 64), Scopes: []string{"limit:check"}}}
+		}},
+		{name: "InvalidAPIKeyHash", mutate: func(c *Config) {
+			c.Auth.APIKeys.Keys = []APIKeyConfig{{ID: "billing", Hash: "plain-key", Scopes: []string{"limit:check"}}}
+		}},
+		{name: "InvalidAPIKeyTenant", mutate: func(c *Config) {
+			c.Auth.APIKeys.Keys = []APIKeyConfig{{ID: "billing", Hash: strings.Repeat("a", 64), Scopes: []string{"limit:check"}, Tenant: "a:b"}}
+		}},
+		{name: "APIKeyWithoutScopes", mutate: func(c *Config) {
+			c.Auth.APIKeys.Keys = []APIKeyConfig{{ID: "billing", Hash: strings.Repeat("a", 64)}}
+		}},
+		{name: "ZeroStartupTimeout", mutate: func(c *Config) { c.Startup.Timeout = 0 }},
+		{name: "NegativeStartupTimeout", mutate: func(c *Config) { c.Startup.Timeout = -time.Second }},
+		{name: "ZeroStartupBackoff", mutate: func(c *Config) { c.Startup.InitialBackoff = 0 }},
+		{name: "StartupBackoffAboveMax", mutate: func(c *Config) { c.Startup.MaxBackoff = c.Startup.InitialBackoff / 2 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := defaultConfig()
+			tt.mutate(c)
+			assert.ErrorIs(t, c.Validate(), ErrInvalidConfig)
+		})
+	}
+}
+
+func TestNewConfig_LogSamplingFromEnv(t *testing.T) {
+	t.Setenv(EnvEnvironment, "production")
+	t.Setenv(EnvLogSamplingInitial, "5")
+	t.Setenv(EnvLogSamplingThereafter, "50")
+	t.Setenv(EnvLogDedupWindow, "30s")
+	c := NewConfig()
+	sampling := c.GetLogSamplingConfig()
+	assert.Equal(t, 5, sampling.Initial)
+	assert.Equal(t, 50, sampling.Thereafter)
+	assert.Equal(t, 30*time.Second, sampling.DedupWindow)
+	assert.Equal(t, DefaultHealthLogSampling, sampling.Routes[DefaultHealthRoute])
+}
+
+func TestLoad_FileOverlay_LogSamplingRoutes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "log:\n  sampling:\n    routes:\n      /metrics: 10\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	t.Setenv(EnvConfigFile, path)
+
+	c, err := Load()
+
+	require.NoError(t, err)
+	routes := c.GetLogSamplingConfig().Routes
+	assert.Equal(t, 10, routes["/metrics"])
+	assert.Equal(t, DefaultHealthLogSampling, routes[DefaultHealthRoute])
+}
+
+func TestNewConfig_LogRedactionFromEnv(t *testing.T) {
+	t.Setenv(EnvLogRedactKeys, "tenant, session_id")
+	t.Setenv(EnvLogRedactMode, RedactModeHash)
+	c := NewConfig()
+	assert.Equal(t, LogRedactionConfig{Keys: []string{"tenant", "session_id"}, Mode: RedactModeHash}, c.GetLogRedactionConfig())
+}
+
+func TestNewConfig_LogSinksFromEnv(t *testing.T) {
+	t.Setenv(EnvLogSinks, "json, file")
+	t.Setenv(EnvLogFilePath, "/var/log/app.log")
+	c := NewConfig()
+	assert.Equal(t, []LogSinkConfig{{Type: LogSinkJSON}, {Type: LogSinkFile, Path: "/var/log/app.log"}}, c.GetLogSinks())
+}
+
+func TestLoad_FileOverlay_LogSinks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "log:\n  sinks:\n    - type: file\n      level: warn\n      path: /tmp/app.log\n      max_size_mb: 50\n      rotate_every: 24h\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	t.Setenv(EnvConfigFile, path)
+
+	c, err := Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, []LogSinkConfig{{Type: LogSinkFile, Level: "warn", Path: "/tmp/app.log", MaxSizeMB: 50, RotateEvery: 24 * time.Hour}}, c.GetLogSinks())
+}
+
+func TestNewConfig_LogBodyFromEnv(t *testing.T) {
+	t.Setenv(EnvLogBodyRoutes, "/api/v1/user,/api/v1/limit/check")
+	t.Setenv(EnvLogBodyMaxBytes, "1024")
+	c := NewConfig()
+	body := c.GetLogBodyConfig()
+	assert.Equal(t, []string{"/api/v1/user", "/api/v1/limit/check"}, body.Routes)
+	assert.Equal(t, 1024, body.MaxBytes)
+	assert.Contains(t, body.Headers, "Content-Type")
+}
+
+func TestNewConfig_TraceExporterFromEnv(t *testing.T) {
+	t.Setenv(EnvTraceExporter, TraceExporterOTLPHTTP)
+	t.Setenv(EnvTraceOTLPTLS, "true")
+	t.Setenv(EnvTraceOTLPHeaders, "api-key=abc, x-tenant = olx,invalid")
+	c := NewConfig()
+	tracing := c.GetTracingConfig()
+	assert.Equal(t, TraceExporterOTLPHTTP, tracing.Exporter)
+	assert.True(t, tracing.TLS)
+	assert.Equal(t, map[string]string{"api-key": "abc", "x-tenant": "olx"}, tracing.Headers)
+	assert.NoError(t, c.Validate())
+}
+
+func TestNewConfig_HealthFromEnv(t *testing.T) {
+	t.Setenv(EnvHealthCheckTimeout, "500ms")
+	t.Setenv(EnvHealthCacheTTL, "1s")
+	c := NewConfig()
+	assert.Equal(t, HealthConfig{Timeout: 500 * time.Millisecond, CacheTTL: time.Second}, c.GetHealthConfig())
+	assert.Equal(t, DefaultHealthLogSampling, c.GetLogSamplingConfig().Routes[DefaultReadinessRoute])
+}
+
+func TestNewConfig_StartupFromEnv(t *testing.T) {
+	t.Setenv(EnvStartupTimeout, "2m")
+	t.Setenv(EnvStartupInitialBackoff, "1s")
+	t.Setenv(EnvStartupMaxBackoff, "10s")
+	c := NewConfig()
+	assert.Equal(t, StartupConfig{Timeout: 2 * time.Minute, InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}, c.GetStartupConfig())
+}
+
+func TestNewConfig_RedisFromEnv(t *testing.T) {
+	t.Setenv(EnvRedisHost, "redis:6380")
+	t.Setenv(EnvRedisUsername, "app")
+	t.Setenv(EnvRedisPassword, "s3cret")
+	t.Setenv(EnvRedisDB, "3")
+	t.Setenv(EnvRedisTLS, "true")
+	t.Setenv(EnvRedisTLSCAFile, "/etc/redis/ca.pem")
+	t.Setenv(EnvRedisTLSServerName, "redis.internal")
+	t.Setenv(EnvRedisDialTimeout, "1s")
+	t.Setenv(EnvRedisReadTimeout, "500ms")
+	t.Setenv(EnvRedisWriteTimeout, "750ms")
+	t.Setenv(EnvRedisPoolSize, "50")
+	t.Setenv(EnvRedisMinIdleConns, "5")
+	t.Setenv(EnvRedisMaxRetries, "-1")
+	t.Setenv(EnvRedisSlowCommandThreshold, "250ms")
+
+	c, err := Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, RedisConfig{
+		Mode: RedisModeStandalone, Host: "redis:6380", Username: "app", Password: "s3cret", DB: 3,
+		TLS:         RedisTLSConfig{Enabled: true, CAFile: "/etc/redis/ca.pem", ServerName: "redis.internal"},
+		DialTimeout: time.Second, ReadTimeout: 500 * time.Millisecond, WriteTimeout: 750 * time.Millisecond,
+		PoolSize: 50, MinIdleConns: 5, MaxRetries: -1, SlowCommandThreshold: 250 * time.Millisecond,
+	}, c.GetRedisConfig())
+	assert.Equal(t, "redis:6380", c.GetRedisHost())
+}
+
+func TestNewConfig_RedisSentinelFromEnv(t *testing.T) {
+	t.Setenv(EnvRedisMode, RedisModeSentinel)
+	t.Setenv(EnvRedisAddrs, "sentinel-1:26379,sentinel-2:26379")
+	t.Setenv(EnvRedisMasterName, "mymaster")
+	t.Setenv(EnvRedisSentinelUsername, "watcher")
+	t.Setenv(EnvRedisSentinelPassword, "sentinel-secret")
+
+	c, err := Load()
+
+	require.NoError(t, err)
+	redis := c.GetRedisConfig()
+	assert.Equal(t, RedisModeSentinel, redis.Mode)
+	assert.Equal(t, []string{"sentinel-1:26379", "sentinel-2:26379"}, redis.Addresses())
+	assert.Equal(t, "mymaster", redis.MasterName)
+	assert.Equal(t, "watcher", redis.SentinelUsername)
+	assert.Equal(t, "sentinel-secret", redis.SentinelPassword)
+}
+
+func TestRedisConfig_Addresses_DefaultsToHost(t *testing.T) {
+	c := NewConfig()
+	assert.Equal(t, RedisModeStandalone, c.Redis.Mode)
+	assert.Equal(t, []string{DefaultRedisHost}, c.Redis.Addresses())
+
+	c.Redis.Host = ""
+	assert.Empty(t, c.Redis.Addresses())
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// HealthConfig bounds the dependency checks behind the readiness probe. Each check is
+// given Timeout, and its result is reused for CacheTTL so frequent probes do not load
+// the dependencies.
+type HealthConfig struct {
+	Timeout  time.Duration `yaml:"timeout"`
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+}
+
+// StartupConfig bounds the startup phase, during which the critical dependencies are
+// retried with exponential backoff from InitialBackoff up to MaxBackoff. Dependencies
+// still unavailable after Timeout keep being retried in the background.
+type StartupConfig struct {
+	Timeout        time.Duration `yaml:"timeout"`
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+}
+
+func (s *StartupConfig) validate() error {
+	if s.Timeout <= 0 {
+		return fmt.Errorf("%w: startup timeout must be positive", ErrInvalidConfig)
+	}
+	if s.InitialBackoff <= 0 || s.MaxBackoff < s.InitialBackoff {
+		return fmt.Errorf("%w: startup backoff must be positive and not exceed its maximum", ErrInvalidConfig)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"time"
+)
+
+// LimitConfig holds the named rate limit policies used by the limit use case.
+type LimitConfig struct {
+	DefaultPolicy string                 `yaml:"default_policy"`
+	Policies      map[string]LimitPolicy `yaml:"policies"`
+}
+
+// LimitPolicy allows Limit requests per Window for a single user.
+type LimitPolicy struct {
+	Limit  int           `yaml:"limit"`
+	Window time.Duration `yaml:"window"`
+}
+
+// Override returns the policies of l with those of override applied on top.
+func (l LimitConfig) Override(override LimitConfig) LimitConfig {
+	merged := LimitConfig{
+		DefaultPolicy: l.DefaultPolicy,
+		Policies:      make(map[string]LimitPolicy, len(l.Policies)+len(override.Policies)),
+	}
+	maps.Copy(merged.Policies, l.Policies)
+	maps.Copy(merged.Policies, override.Policies)
+	if override.DefaultPolicy != EmptyString {
+		merged.DefaultPolicy = override.DefaultPolicy
+	}
+	return merged
+}
+
+// Policy returns the named policy, falling back to the default policy when name is empty.
+func (l LimitConfig) Policy(name string) (string, LimitPolicy, bool) {
+	if name == EmptyString {
+		name = l.DefaultPolicy
+	}
+	policy, ok := l.Policies[name]
+	return name, policy, ok
+}
+
+func (l *LimitConfig) validate() error {
+	for name, policy := range l.Policies {
+		if policy.Limit <= 0 || policy.Window <= 0 {
+			return fmt.Errorf("%w: limit policy %q needs a positive limit and window", ErrInvalidConfig, name)
+		}
+	}
+	if _, _, ok := l.Policy(EmptyString); !ok {
+		return fmt.Errorf("%w: default limit policy %q is not defined", ErrInvalidConfig, l.DefaultPolicy)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+type LogConfig struct {
+	Level     string             `yaml:"level"`
+	Sampling  LogSamplingConfig  `yaml:"sampling"`
+	Redaction LogRedactionConfig `yaml:"redaction"`
+	Sinks     []LogSinkConfig    `yaml:"sinks"`
+	Body      LogBodyConfig      `yaml:"body_capture"`
+}
+
+// LogBodyConfig selects the requests whose bodies are logged: those matching Routes
+// (route templates such as "/api/v1/user"), and any request sending X-Debug-Body with a
+// valid admin token. Bodies are truncated to MaxBytes.
+type LogBodyConfig struct {
+	Routes   []string `yaml:"routes"`
+	MaxBytes int      `yaml:"max_bytes"`
+	Headers  []string `yaml:"headers"`
+}
+
+// LogSinkConfig is one destination for log entries: "console", "json" (stdout), "file"
+// or "otlp". Level, when set, raises the minimum level for this sink only.
+type LogSinkConfig struct {
+	Type  string `yaml:"type"`
+	Level string `yaml:"level"`
+	// File sink: size-based rotation (MaxSizeMB) and optional time-based rotation.
+	Path        string        `yaml:"path"`
+	MaxSizeMB   int           `yaml:"max_size_mb"`
+	MaxBackups  int           `yaml:"max_backups"`
+	MaxAgeDays  int           `yaml:"max_age_days"`
+	Compress    bool          `yaml:"compress"`
+	RotateEvery time.Duration `yaml:"rotate_every"`
+	// OTLP sink: Endpoint defaults to server.otlp_endpoint.
+	Endpoint string `yaml:"endpoint"`
+	TLS      bool   `yaml:"tls"`
+}
+
+// LogRedactionConfig extends the logger's built-in deny-list of keys and chooses whether
+// sensitive values are masked or replaced by a hash.
+type LogRedactionConfig struct {
+	Keys []string `yaml:"keys"`
+	Mode string   `yaml:"mode"`
+}
+
+// LogSamplingConfig limits the log volume under load. Initial 0 disables sampling and
+// DedupWindow 0 disables deduplication of repeated errors.
+type LogSamplingConfig struct {
+	// Initial entries with the same level and message are written per second, then
+	// every Thereafter-th one.
+	Initial    int `yaml:"initial"`
+	Thereafter int `yaml:"thereafter"`
+	// Routes writes one in N Debug and Info entries for the given route templates.
+	Routes map[string]int `yaml:"routes"`
+	// DedupWindow suppresses repeats of an identical error for this long.
+	DedupWindow time.Duration `yaml:"dedup_window"`
+}
+
+func (l *LogConfig) validate() error {
+	if _, ok := logLevels[strings.ToLower(l.Level)]; !ok {
+		return fmt.Errorf("%w: unknown log level %q", ErrInvalidConfig, l.Level)
+	}
+	if _, ok := redactModes[l.Redaction.Mode]; !ok {
+		return fmt.Errorf("%w: unknown log redaction mode %q", ErrInvalidConfig, l.Redaction.Mode)
+	}
+	if l.Body.MaxBytes <= 0 || l.Body.MaxBytes > MaxLogBodyBytes {
+		return fmt.Errorf("%w: log body capture limit must be between 1 and %d bytes", ErrInvalidConfig, MaxLogBodyBytes)
+	}
+	if len(l.Sinks) == 0 {
+		return fmt.Errorf("%w: at least one log sink is required", ErrInvalidConfig)
+	}
+	for _, sink := range l.Sinks {
+		if err := sink.validate(); err != nil {
+			return err
+		}
+	}
+	return l.Sampling.validate()
+}
+
+func (s *LogSinkConfig) validate() error {
+	if _, ok := logSinks[s.Type]; !ok {
+		return fmt.Errorf("%w: unknown log sink %q", ErrInvalidConfig, s.Type)
+	}
+	if _, ok := logLevels[strings.ToLower(s.Level)]; s.Level != EmptyString && !ok {
+		return fmt.Errorf("%w: unknown level %q for log sink %q", ErrInvalidConfig, s.Level, s.Type)
+	}
+	if s.Type == LogSinkFile && s.Path == EmptyString {
+		return fmt.Errorf("%w: file log sink needs a path", ErrInvalidConfig)
+	}
+	if s.MaxSizeMB < 0 || s.MaxBackups < 0 || s.MaxAgeDays < 0 || s.RotateEvery < 0 {
+		return fmt.Errorf("%w: log sink %q settings must not be negative", ErrInvalidConfig, s.Type)
+	}
+	return nil
+}
+
+func (s LogSamplingConfig) validate() error {
+	if s.Initial < 0 || s.Thereafter < 0 || s.DedupWindow < 0 {
+		return fmt.Errorf("%w: log sampling settings must not be negative", ErrInvalidConfig)
+	}
+	for route, n := range s.Routes {
+		if n < 1 {
+			return fmt.Errorf("%w: log sampling for route %q must be at least 1", ErrInvalidConfig, route)
+		}
+	}
+	return nil
+}
+
+func defaultLogConfig() LogConfig {
+	return LogConfig{
+		Level: DefaultLogLevel,
+		Sampling: LogSamplingConfig{
+			Initial:    DefaultLogSamplingInitial,
+			Thereafter: DefaultLogSamplingThereafter,
+			Routes: map[string]int{
+				DefaultHealthRoute:    DefaultHealthLogSampling,
+				DefaultLivenessRoute:  DefaultHealthLogSampling,
+				DefaultReadinessRoute: DefaultHealthLogSampling,
+			},
+			DedupWindow: DefaultLogDedupWindow,
+		},
+		Redaction: LogRedactionConfig{
+			Mode: RedactModeMask,
+		},
+		Sinks: []LogSinkConfig{{Type: LogSinkJSON}},
+		Body: LogBodyConfig{
+			MaxBytes: DefaultLogBodyMaxBytes,
+			Headers:  []string{"Content-Type", "Content-Length", "Accept", "User-Agent"},
+		},
+	}
+}
+
+// applySinkEnv replaces the sinks with LOG_SINKS, a comma-separated list of sink types.
+// The file sink writes to LOG_FILE_PATH.
+func (c *Config) applySinkEnv() {
+	types := getEnvAsSlice(EnvLogSinks, nil)
+	if len(types) == 0 {
+		return
+	}
+	sinks := make([]LogSinkConfig, 0, len(types))
+	for _, sinkType := range types {
+		sink := LogSinkConfig{Type: sinkType}
+		if sinkType == LogSinkFile {
+			sink.Path = os.Getenv(EnvLogFilePath)
+		}
+		sinks = append(sinks, sink)
+	}
+	c.Log.Sinks = sinks
+}
+
+//nolint:gochecknoglobals // Read-only lookup table of accepted LOG_LEVEL values.
+var logLevels = map[string]struct{}{
+	"debug": {},
+	"info":  {},
+	"warn":  {},
+	"error": {},
+}
+
+//nolint:gochecknoglobals // Read-only lookup table of accepted log sink types.
+var logSinks = map[string]struct{}{
+	LogSinkConsole: {},
+	LogSinkJSON:    {},
+	LogSinkFile:    {},
+	LogSinkOTLP:    {},
+}
+
+//nolint:gochecknoglobals // Read-only lookup table of accepted log redaction modes.
+var redactModes = map[string]struct{}{
+	RedactModeMask: {},
+	RedactModeHash: {},
+}
+
+const (
+	LogSinkConsole = "console"
+	LogSinkJSON    = "json"
+	LogSinkFile    = "file"
+	LogSinkOTLP    = "otlp"
+)
+
+const (
+	RedactModeMask = "mask"
+	RedactModeHash = "hash"
+)
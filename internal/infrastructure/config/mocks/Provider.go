@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	"go-service-template/internal/infrastructure/config"
 	"time"
 
 	mock "github.com/stretchr/testify/mock"
@@ -37,90 +38,534 @@ func (_m *Provider) EXPECT() *Provider_Expecter {
 	return &Provider_Expecter{mock: &_m.Mock}
 }
 
+// GetAdminToken provides a mock function for the type Provider
+func (_mock *Provider) GetAdminToken() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdminToken")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Provider_GetAdminToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAdminToken'
+type Provider_GetAdminToken_Call struct {
+	*mock.Call
+}
+
+// GetAdminToken is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetAdminToken() *Provider_GetAdminToken_Call {
+	return &Provider_GetAdminToken_Call{Call: _e.mock.On("GetAdminToken")}
+}
+
+func (_c *Provider_GetAdminToken_Call) Run(run func()) *Provider_GetAdminToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetAdminToken_Call) Return(s string) *Provider_GetAdminToken_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Provider_GetAdminToken_Call) RunAndReturn(run func() string) *Provider_GetAdminToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAppName provides a mock function for the type Provider
 func (_mock *Provider) GetAppName() string {
 	ret := _mock.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAppName")
+		panic("no return value specified for GetAppName")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Provider_GetAppName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppName'
+type Provider_GetAppName_Call struct {
+	*mock.Call
+}
+
+// GetAppName is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetAppName() *Provider_GetAppName_Call {
+	return &Provider_GetAppName_Call{Call: _e.mock.On("GetAppName")}
+}
+
+func (_c *Provider_GetAppName_Call) Run(run func()) *Provider_GetAppName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetAppName_Call) Return(s string) *Provider_GetAppName_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Provider_GetAppName_Call) RunAndReturn(run func() string) *Provider_GetAppName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthConfig provides a mock function for the type Provider
+func (_mock *Provider) GetAuthConfig() config.AuthConfig {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthConfig")
+	}
+
+	var r0 config.AuthConfig
+	if returnFunc, ok := ret.Get(0).(func() config.AuthConfig); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(config.AuthConfig)
+	}
+	return r0
+}
+
+// Provider_GetAuthConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthConfig'
+type Provider_GetAuthConfig_Call struct {
+	*mock.Call
+}
+
+// GetAuthConfig is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetAuthConfig() *Provider_GetAuthConfig_Call {
+	return &Provider_GetAuthConfig_Call{Call: _e.mock.On("GetAuthConfig")}
+}
+
+func (_c *Provider_GetAuthConfig_Call) Run(run func()) *Provider_GetAuthConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetAuthConfig_Call) Return(authConfig config.AuthConfig) *Provider_GetAuthConfig_Call {
+	_c.Call.Return(authConfig)
+	return _c
+}
+
+func (_c *Provider_GetAuthConfig_Call) RunAndReturn(run func() config.AuthConfig) *Provider_GetAuthConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCORSAllowedOrigins provides a mock function for the type Provider
+func (_mock *Provider) GetCORSAllowedOrigins() []string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCORSAllowedOrigins")
+	}
+
+	var r0 []string
+	if returnFunc, ok := ret.Get(0).(func() []string); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	return r0
+}
+
+// Provider_GetCORSAllowedOrigins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCORSAllowedOrigins'
+type Provider_GetCORSAllowedOrigins_Call struct {
+	*mock.Call
+}
+
+// GetCORSAllowedOrigins is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetCORSAllowedOrigins() *Provider_GetCORSAllowedOrigins_Call {
+	return &Provider_GetCORSAllowedOrigins_Call{Call: _e.mock.On("GetCORSAllowedOrigins")}
+}
+
+func (_c *Provider_GetCORSAllowedOrigins_Call) Run(run func()) *Provider_GetCORSAllowedOrigins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetCORSAllowedOrigins_Call) Return(strings []string) *Provider_GetCORSAllowedOrigins_Call {
+	_c.Call.Return(strings)
+	return _c
+}
+
+func (_c *Provider_GetCORSAllowedOrigins_Call) RunAndReturn(run func() []string) *Provider_GetCORSAllowedOrigins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEnv provides a mock function for the type Provider
+func (_mock *Provider) GetEnv() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEnv")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Provider_GetEnv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEnv'
+type Provider_GetEnv_Call struct {
+	*mock.Call
+}
+
+// GetEnv is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetEnv() *Provider_GetEnv_Call {
+	return &Provider_GetEnv_Call{Call: _e.mock.On("GetEnv")}
+}
+
+func (_c *Provider_GetEnv_Call) Run(run func()) *Provider_GetEnv_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetEnv_Call) Return(s string) *Provider_GetEnv_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Provider_GetEnv_Call) RunAndReturn(run func() string) *Provider_GetEnv_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHealthConfig provides a mock function for the type Provider
+func (_mock *Provider) GetHealthConfig() config.HealthConfig {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHealthConfig")
+	}
+
+	var r0 config.HealthConfig
+	if returnFunc, ok := ret.Get(0).(func() config.HealthConfig); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(config.HealthConfig)
+	}
+	return r0
+}
+
+// Provider_GetHealthConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHealthConfig'
+type Provider_GetHealthConfig_Call struct {
+	*mock.Call
+}
+
+// GetHealthConfig is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetHealthConfig() *Provider_GetHealthConfig_Call {
+	return &Provider_GetHealthConfig_Call{Call: _e.mock.On("GetHealthConfig")}
+}
+
+func (_c *Provider_GetHealthConfig_Call) Run(run func()) *Provider_GetHealthConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetHealthConfig_Call) Return(healthConfig config.HealthConfig) *Provider_GetHealthConfig_Call {
+	_c.Call.Return(healthConfig)
+	return _c
+}
+
+func (_c *Provider_GetHealthConfig_Call) RunAndReturn(run func() config.HealthConfig) *Provider_GetHealthConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLimitConfig provides a mock function for the type Provider
+func (_mock *Provider) GetLimitConfig() config.LimitConfig {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLimitConfig")
+	}
+
+	var r0 config.LimitConfig
+	if returnFunc, ok := ret.Get(0).(func() config.LimitConfig); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(config.LimitConfig)
+	}
+	return r0
+}
+
+// Provider_GetLimitConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLimitConfig'
+type Provider_GetLimitConfig_Call struct {
+	*mock.Call
+}
+
+// GetLimitConfig is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetLimitConfig() *Provider_GetLimitConfig_Call {
+	return &Provider_GetLimitConfig_Call{Call: _e.mock.On("GetLimitConfig")}
+}
+
+func (_c *Provider_GetLimitConfig_Call) Run(run func()) *Provider_GetLimitConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetLimitConfig_Call) Return(limitConfig config.LimitConfig) *Provider_GetLimitConfig_Call {
+	_c.Call.Return(limitConfig)
+	return _c
+}
+
+func (_c *Provider_GetLimitConfig_Call) RunAndReturn(run func() config.LimitConfig) *Provider_GetLimitConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLogBodyConfig provides a mock function for the type Provider
+func (_mock *Provider) GetLogBodyConfig() config.LogBodyConfig {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogBodyConfig")
+	}
+
+	var r0 config.LogBodyConfig
+	if returnFunc, ok := ret.Get(0).(func() config.LogBodyConfig); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(config.LogBodyConfig)
+	}
+	return r0
+}
+
+// Provider_GetLogBodyConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogBodyConfig'
+type Provider_GetLogBodyConfig_Call struct {
+	*mock.Call
+}
+
+// GetLogBodyConfig is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetLogBodyConfig() *Provider_GetLogBodyConfig_Call {
+	return &Provider_GetLogBodyConfig_Call{Call: _e.mock.On("GetLogBodyConfig")}
+}
+
+func (_c *Provider_GetLogBodyConfig_Call) Run(run func()) *Provider_GetLogBodyConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetLogBodyConfig_Call) Return(logBodyConfig config.LogBodyConfig) *Provider_GetLogBodyConfig_Call {
+	_c.Call.Return(logBodyConfig)
+	return _c
+}
+
+func (_c *Provider_GetLogBodyConfig_Call) RunAndReturn(run func() config.LogBodyConfig) *Provider_GetLogBodyConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLogLevel provides a mock function for the type Provider
+func (_mock *Provider) GetLogLevel() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogLevel")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Provider_GetLogLevel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogLevel'
+type Provider_GetLogLevel_Call struct {
+	*mock.Call
+}
+
+// GetLogLevel is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetLogLevel() *Provider_GetLogLevel_Call {
+	return &Provider_GetLogLevel_Call{Call: _e.mock.On("GetLogLevel")}
+}
+
+func (_c *Provider_GetLogLevel_Call) Run(run func()) *Provider_GetLogLevel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetLogLevel_Call) Return(s string) *Provider_GetLogLevel_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Provider_GetLogLevel_Call) RunAndReturn(run func() string) *Provider_GetLogLevel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLogRedactionConfig provides a mock function for the type Provider
+func (_mock *Provider) GetLogRedactionConfig() config.LogRedactionConfig {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogRedactionConfig")
+	}
+
+	var r0 config.LogRedactionConfig
+	if returnFunc, ok := ret.Get(0).(func() config.LogRedactionConfig); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(config.LogRedactionConfig)
+	}
+	return r0
+}
+
+// Provider_GetLogRedactionConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogRedactionConfig'
+type Provider_GetLogRedactionConfig_Call struct {
+	*mock.Call
+}
+
+// GetLogRedactionConfig is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetLogRedactionConfig() *Provider_GetLogRedactionConfig_Call {
+	return &Provider_GetLogRedactionConfig_Call{Call: _e.mock.On("GetLogRedactionConfig")}
+}
+
+func (_c *Provider_GetLogRedactionConfig_Call) Run(run func()) *Provider_GetLogRedactionConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetLogRedactionConfig_Call) Return(logRedactionConfig config.LogRedactionConfig) *Provider_GetLogRedactionConfig_Call {
+	_c.Call.Return(logRedactionConfig)
+	return _c
+}
+
+func (_c *Provider_GetLogRedactionConfig_Call) RunAndReturn(run func() config.LogRedactionConfig) *Provider_GetLogRedactionConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLogSamplingConfig provides a mock function for the type Provider
+func (_mock *Provider) GetLogSamplingConfig() config.LogSamplingConfig {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogSamplingConfig")
 	}
 
-	var r0 string
-	if returnFunc, ok := ret.Get(0).(func() string); ok {
+	var r0 config.LogSamplingConfig
+	if returnFunc, ok := ret.Get(0).(func() config.LogSamplingConfig); ok {
 		r0 = returnFunc()
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(config.LogSamplingConfig)
 	}
 	return r0
 }
 
-// Provider_GetAppName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppName'
-type Provider_GetAppName_Call struct {
+// Provider_GetLogSamplingConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogSamplingConfig'
+type Provider_GetLogSamplingConfig_Call struct {
 	*mock.Call
 }
 
-// GetAppName is a helper method to define mock.On call
-func (_e *Provider_Expecter) GetAppName() *Provider_GetAppName_Call {
-	return &Provider_GetAppName_Call{Call: _e.mock.On("GetAppName")}
+// GetLogSamplingConfig is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetLogSamplingConfig() *Provider_GetLogSamplingConfig_Call {
+	return &Provider_GetLogSamplingConfig_Call{Call: _e.mock.On("GetLogSamplingConfig")}
 }
 
-func (_c *Provider_GetAppName_Call) Run(run func()) *Provider_GetAppName_Call {
+func (_c *Provider_GetLogSamplingConfig_Call) Run(run func()) *Provider_GetLogSamplingConfig_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *Provider_GetAppName_Call) Return(s string) *Provider_GetAppName_Call {
-	_c.Call.Return(s)
+func (_c *Provider_GetLogSamplingConfig_Call) Return(logSamplingConfig config.LogSamplingConfig) *Provider_GetLogSamplingConfig_Call {
+	_c.Call.Return(logSamplingConfig)
 	return _c
 }
 
-func (_c *Provider_GetAppName_Call) RunAndReturn(run func() string) *Provider_GetAppName_Call {
+func (_c *Provider_GetLogSamplingConfig_Call) RunAndReturn(run func() config.LogSamplingConfig) *Provider_GetLogSamplingConfig_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEnv provides a mock function for the type Provider
-func (_mock *Provider) GetEnv() string {
+// GetLogSinks provides a mock function for the type Provider
+func (_mock *Provider) GetLogSinks() []config.LogSinkConfig {
 	ret := _mock.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEnv")
+		panic("no return value specified for GetLogSinks")
 	}
 
-	var r0 string
-	if returnFunc, ok := ret.Get(0).(func() string); ok {
+	var r0 []config.LogSinkConfig
+	if returnFunc, ok := ret.Get(0).(func() []config.LogSinkConfig); ok {
 		r0 = returnFunc()
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]config.LogSinkConfig)
+		}
 	}
 	return r0
 }
 
-// Provider_GetEnv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEnv'
-type Provider_GetEnv_Call struct {
+// Provider_GetLogSinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogSinks'
+type Provider_GetLogSinks_Call struct {
 	*mock.Call
 }
 
-// GetEnv is a helper method to define mock.On call
-func (_e *Provider_Expecter) GetEnv() *Provider_GetEnv_Call {
-	return &Provider_GetEnv_Call{Call: _e.mock.On("GetEnv")}
+// GetLogSinks is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetLogSinks() *Provider_GetLogSinks_Call {
+	return &Provider_GetLogSinks_Call{Call: _e.mock.On("GetLogSinks")}
 }
 
-func (_c *Provider_GetEnv_Call) Run(run func()) *Provider_GetEnv_Call {
+func (_c *Provider_GetLogSinks_Call) Run(run func()) *Provider_GetLogSinks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *Provider_GetEnv_Call) Return(s string) *Provider_GetEnv_Call {
-	_c.Call.Return(s)
+func (_c *Provider_GetLogSinks_Call) Return(logSinkConfigs []config.LogSinkConfig) *Provider_GetLogSinks_Call {
+	_c.Call.Return(logSinkConfigs)
 	return _c
 }
 
-func (_c *Provider_GetEnv_Call) RunAndReturn(run func() string) *Provider_GetEnv_Call {
+func (_c *Provider_GetLogSinks_Call) RunAndReturn(run func() []config.LogSinkConfig) *Provider_GetLogSinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -169,6 +614,94 @@ func (_c *Provider_GetOTLPEndpoint_Call) RunAndReturn(run func() string) *Provid
 	return _c
 }
 
+// GetProfile provides a mock function for the type Provider
+func (_mock *Provider) GetProfile() config.Profile {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfile")
+	}
+
+	var r0 config.Profile
+	if returnFunc, ok := ret.Get(0).(func() config.Profile); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(config.Profile)
+	}
+	return r0
+}
+
+// Provider_GetProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfile'
+type Provider_GetProfile_Call struct {
+	*mock.Call
+}
+
+// GetProfile is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetProfile() *Provider_GetProfile_Call {
+	return &Provider_GetProfile_Call{Call: _e.mock.On("GetProfile")}
+}
+
+func (_c *Provider_GetProfile_Call) Run(run func()) *Provider_GetProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetProfile_Call) Return(profile config.Profile) *Provider_GetProfile_Call {
+	_c.Call.Return(profile)
+	return _c
+}
+
+func (_c *Provider_GetProfile_Call) RunAndReturn(run func() config.Profile) *Provider_GetProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRedisConfig provides a mock function for the type Provider
+func (_mock *Provider) GetRedisConfig() config.RedisConfig {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRedisConfig")
+	}
+
+	var r0 config.RedisConfig
+	if returnFunc, ok := ret.Get(0).(func() config.RedisConfig); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(config.RedisConfig)
+	}
+	return r0
+}
+
+// Provider_GetRedisConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRedisConfig'
+type Provider_GetRedisConfig_Call struct {
+	*mock.Call
+}
+
+// GetRedisConfig is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetRedisConfig() *Provider_GetRedisConfig_Call {
+	return &Provider_GetRedisConfig_Call{Call: _e.mock.On("GetRedisConfig")}
+}
+
+func (_c *Provider_GetRedisConfig_Call) Run(run func()) *Provider_GetRedisConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetRedisConfig_Call) Return(redisConfig config.RedisConfig) *Provider_GetRedisConfig_Call {
+	_c.Call.Return(redisConfig)
+	return _c
+}
+
+func (_c *Provider_GetRedisConfig_Call) RunAndReturn(run func() config.RedisConfig) *Provider_GetRedisConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetRedisHost provides a mock function for the type Provider
 func (_mock *Provider) GetRedisHost() string {
 	ret := _mock.Called()
@@ -388,3 +921,276 @@ func (_c *Provider_GetServerWriteTimeout_Call) RunAndReturn(run func() time.Dura
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetStartupConfig provides a mock function for the type Provider
+func (_mock *Provider) GetStartupConfig() config.StartupConfig {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStartupConfig")
+	}
+
+	var r0 config.StartupConfig
+	if returnFunc, ok := ret.Get(0).(func() config.StartupConfig); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(config.StartupConfig)
+	}
+	return r0
+}
+
+// Provider_GetStartupConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStartupConfig'
+type Provider_GetStartupConfig_Call struct {
+	*mock.Call
+}
+
+// GetStartupConfig is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetStartupConfig() *Provider_GetStartupConfig_Call {
+	return &Provider_GetStartupConfig_Call{Call: _e.mock.On("GetStartupConfig")}
+}
+
+func (_c *Provider_GetStartupConfig_Call) Run(run func()) *Provider_GetStartupConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetStartupConfig_Call) Return(startupConfig config.StartupConfig) *Provider_GetStartupConfig_Call {
+	_c.Call.Return(startupConfig)
+	return _c
+}
+
+func (_c *Provider_GetStartupConfig_Call) RunAndReturn(run func() config.StartupConfig) *Provider_GetStartupConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTenancyConfig provides a mock function for the type Provider
+func (_mock *Provider) GetTenancyConfig() config.TenancyConfig {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTenancyConfig")
+	}
+
+	var r0 config.TenancyConfig
+	if returnFunc, ok := ret.Get(0).(func() config.TenancyConfig); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(config.TenancyConfig)
+	}
+	return r0
+}
+
+// Provider_GetTenancyConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTenancyConfig'
+type Provider_GetTenancyConfig_Call struct {
+	*mock.Call
+}
+
+// GetTenancyConfig is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetTenancyConfig() *Provider_GetTenancyConfig_Call {
+	return &Provider_GetTenancyConfig_Call{Call: _e.mock.On("GetTenancyConfig")}
+}
+
+func (_c *Provider_GetTenancyConfig_Call) Run(run func()) *Provider_GetTenancyConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetTenancyConfig_Call) Return(tenancyConfig config.TenancyConfig) *Provider_GetTenancyConfig_Call {
+	_c.Call.Return(tenancyConfig)
+	return _c
+}
+
+func (_c *Provider_GetTenancyConfig_Call) RunAndReturn(run func() config.TenancyConfig) *Provider_GetTenancyConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTenantLimitConfig provides a mock function for the type Provider
+func (_mock *Provider) GetTenantLimitConfig(tenant string) config.LimitConfig {
+	ret := _mock.Called(tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTenantLimitConfig")
+	}
+
+	var r0 config.LimitConfig
+	if returnFunc, ok := ret.Get(0).(func(string) config.LimitConfig); ok {
+		r0 = returnFunc(tenant)
+	} else {
+		r0 = ret.Get(0).(config.LimitConfig)
+	}
+	return r0
+}
+
+// Provider_GetTenantLimitConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTenantLimitConfig'
+type Provider_GetTenantLimitConfig_Call struct {
+	*mock.Call
+}
+
+// GetTenantLimitConfig is a helper method to define mock.On call
+//   - tenant string
+func (_e *Provider_Expecter) GetTenantLimitConfig(tenant interface{}) *Provider_GetTenantLimitConfig_Call {
+	return &Provider_GetTenantLimitConfig_Call{Call: _e.mock.On("GetTenantLimitConfig", tenant)}
+}
+
+func (_c *Provider_GetTenantLimitConfig_Call) Run(run func(tenant string)) *Provider_GetTenantLimitConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Provider_GetTenantLimitConfig_Call) Return(limitConfig config.LimitConfig) *Provider_GetTenantLimitConfig_Call {
+	_c.Call.Return(limitConfig)
+	return _c
+}
+
+func (_c *Provider_GetTenantLimitConfig_Call) RunAndReturn(run func(tenant string) config.LimitConfig) *Provider_GetTenantLimitConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTracingConfig provides a mock function for the type Provider
+func (_mock *Provider) GetTracingConfig() config.TracingConfig {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTracingConfig")
+	}
+
+	var r0 config.TracingConfig
+	if returnFunc, ok := ret.Get(0).(func() config.TracingConfig); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(config.TracingConfig)
+	}
+	return r0
+}
+
+// Provider_GetTracingConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTracingConfig'
+type Provider_GetTracingConfig_Call struct {
+	*mock.Call
+}
+
+// GetTracingConfig is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetTracingConfig() *Provider_GetTracingConfig_Call {
+	return &Provider_GetTracingConfig_Call{Call: _e.mock.On("GetTracingConfig")}
+}
+
+func (_c *Provider_GetTracingConfig_Call) Run(run func()) *Provider_GetTracingConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetTracingConfig_Call) Return(tracingConfig config.TracingConfig) *Provider_GetTracingConfig_Call {
+	_c.Call.Return(tracingConfig)
+	return _c
+}
+
+func (_c *Provider_GetTracingConfig_Call) RunAndReturn(run func() config.TracingConfig) *Provider_GetTracingConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserAPIConfig provides a mock function for the type Provider
+func (_mock *Provider) GetUserAPIConfig() config.UserAPIConfig {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserAPIConfig")
+	}
+
+	var r0 config.UserAPIConfig
+	if returnFunc, ok := ret.Get(0).(func() config.UserAPIConfig); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(config.UserAPIConfig)
+	}
+	return r0
+}
+
+// Provider_GetUserAPIConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAPIConfig'
+type Provider_GetUserAPIConfig_Call struct {
+	*mock.Call
+}
+
+// GetUserAPIConfig is a helper method to define mock.On call
+func (_e *Provider_Expecter) GetUserAPIConfig() *Provider_GetUserAPIConfig_Call {
+	return &Provider_GetUserAPIConfig_Call{Call: _e.mock.On("GetUserAPIConfig")}
+}
+
+func (_c *Provider_GetUserAPIConfig_Call) Run(run func()) *Provider_GetUserAPIConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Provider_GetUserAPIConfig_Call) Return(userAPIConfig config.UserAPIConfig) *Provider_GetUserAPIConfig_Call {
+	_c.Call.Return(userAPIConfig)
+	return _c
+}
+
+func (_c *Provider_GetUserAPIConfig_Call) RunAndReturn(run func() config.UserAPIConfig) *Provider_GetUserAPIConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribe provides a mock function for the type Provider
+func (_mock *Provider) Subscribe(name string, fn config.Subscriber) {
+	_mock.Called(name, fn)
+	return
+}
+
+// Provider_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type Provider_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - name string
+//   - fn config.Subscriber
+func (_e *Provider_Expecter) Subscribe(name interface{}, fn interface{}) *Provider_Subscribe_Call {
+	return &Provider_Subscribe_Call{Call: _e.mock.On("Subscribe", name, fn)}
+}
+
+func (_c *Provider_Subscribe_Call) Run(run func(name string, fn config.Subscriber)) *Provider_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 config.Subscriber
+		if args[1] != nil {
+			arg1 = args[1].(config.Subscriber)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Provider_Subscribe_Call) Return() *Provider_Subscribe_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Provider_Subscribe_Call) RunAndReturn(run func(name string, fn config.Subscriber)) *Provider_Subscribe_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Profile is a named set of defaults for a deployment environment.
+type Profile string
+
+const (
+	ProfileLocal      Profile = "local"
+	ProfileTest       Profile = "test"
+	ProfileStaging    Profile = "staging"
+	ProfileProduction Profile = "production"
+)
+
+// ProfileFor resolves the profile for an ENV label such as "stg" or "local-docker".
+// Unknown labels fall back to the local profile; see ParseProfile for PROFILE.
+func ProfileFor(env string) Profile {
+	switch strings.ToLower(env) {
+	case "test", "ci":
+		return ProfileTest
+	case "stg", "staging":
+		return ProfileStaging
+	case "prod", "production":
+		return ProfileProduction
+	default:
+		return ProfileLocal
+	}
+}
+
+// ParseProfile returns the profile named name. Unlike ENV labels, an explicit profile name
+// must be known: a typo would otherwise run the local defaults.
+func ParseProfile(name string) (Profile, error) {
+	profile := Profile(strings.ToLower(name))
+	switch profile {
+	case ProfileLocal, ProfileTest, ProfileStaging, ProfileProduction:
+		return profile, nil
+	default:
+		return ProfileLocal, fmt.Errorf("%w: %q", ErrUnknownProfile, name)
+	}
+}
+
+// applyProfile overlays the profile's defaults on the base defaults.
+func (c *Config) applyProfile(profile Profile) {
+	c.Profile = profile
+	switch profile {
+	case ProfileLocal:
+		c.Log.Level = "debug"
+		c.Log.Sampling.Initial = 0
+		c.Log.Sampling.DedupWindow = 0
+		c.Log.Sinks = []LogSinkConfig{{Type: LogSinkConsole}}
+		c.Tracing.Sampler = SamplerAlways
+	case ProfileTest:
+		c.Log.Level = "warn"
+		c.Tracing.Sampler = SamplerAlways
+	case ProfileStaging:
+		c.Log.Sinks = shippedLogSinks()
+		c.CORS.AllowedOrigins = nil
+		c.Tracing.Sampler = SamplerParentRatio
+		c.Tracing.SampleRatio = stagingSampleRatio
+	case ProfileProduction:
+		c.Log.Sinks = shippedLogSinks()
+		c.CORS.AllowedOrigins = nil
+		c.Tracing.Sampler = SamplerParentRatio
+		c.Tracing.SampleRatio = productionSampleRatio
+	}
+}
+
+// shippedLogSinks writes JSON to stdout and ships Info and above over OTLP alongside traces.
+func shippedLogSinks() []LogSinkConfig {
+	return []LogSinkConfig{{Type: LogSinkJSON}, {Type: LogSinkOTLP, Level: "info"}}
+}
+
+// profileFile returns the overlay file for the profile, or "" when the profile has none.
+func profileFile(profile Profile) string {
+	path := filepath.Join(getEnv(EnvConfigDir, DefaultConfigDir), string(profile)+".yaml")
+	if _, err := os.Stat(path); err != nil {
+		return EmptyString
+	}
+	return path
+}
+
+// validateProfile refuses settings that are acceptable locally but insecure in production.
+func (c *Config) validateProfile() error {
+	if err := c.validateAuthRequired(); err != nil {
+		return err
+	}
+	if c.Profile != ProfileProduction {
+		return nil
+	}
+	if slices.Contains(c.CORS.AllowedOrigins, WildcardOrigin) {
+		return fmt.Errorf("%w: wildcard CORS origin", ErrInsecureConfig)
+	}
+	if strings.EqualFold(c.Log.Level, "debug") {
+		return fmt.Errorf("%w: debug log level", ErrInsecureConfig)
+	}
+	if c.Admin.Token != EmptyString && len(c.Admin.Token) < minProductionAdminTokenLength {
+		return fmt.Errorf("%w: admin token shorter than %d characters", ErrInsecureConfig, minProductionAdminTokenLength)
+	}
+	if strings.HasPrefix(c.Auth.JWT.JWKSURL, "http://") {
+		return fmt.Errorf("%w: JWKS fetched over plain HTTP", ErrInsecureConfig)
+	}
+	return nil
+}
+
+// validateAuthRequired refuses deployed profiles without any authenticator: the API routes
+// require scopes, and only the local and test profiles may serve them unauthenticated.
+func (c *Config) validateAuthRequired() error {
+	if c.Profile == ProfileLocal || c.Profile == ProfileTest || c.Auth.Enabled() {
+		return nil
+	}
+	return fmt.Errorf("%w: profile %s needs JWT_SECRET, JWT_JWKS_URL, JWT_JWKS_FILE or API keys", ErrNoAuthentication, c.Profile)
+}
+
+var (
+	ErrInsecureConfig   = errors.New("insecure configuration for production profile")
+	ErrNoAuthentication = errors.New("no API authentication configured")
+	ErrUnknownProfile   = errors.New("unknown configuration profile")
+)
+
+const minProductionAdminTokenLength = 32
+
+const (
+	stagingSampleRatio    = 0.5
+	productionSampleRatio = 0.1
+)
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileFor_MapsEnvLabels(t *testing.T) {
+	tests := map[string]Profile{
+		"local":        ProfileLocal,
+		"local-docker": ProfileLocal,
+		"test":         ProfileTest,
+		"stg":          ProfileStaging,
+		"staging":      ProfileStaging,
+		"prod":         ProfileProduction,
+		"Production":   ProfileProduction,
+		"unknown":      ProfileLocal,
+	}
+	for env, want := range tests {
+		t.Run(env, func(t *testing.T) {
+			assert.Equal(t, want, ProfileFor(env))
+		})
+	}
+}
+
+func TestNewConfig_LocalProfileDefaults(t *testing.T) {
+	os.Clearenv()
+	c := NewConfig()
+	assert.Equal(t, ProfileLocal, c.GetProfile())
+	assert.Equal(t, "debug", c.GetLogLevel())
+	assert.Zero(t, c.GetLogSamplingConfig().Initial)
+	assert.Zero(t, c.GetLogSamplingConfig().DedupWindow)
+	assert.Equal(t, []LogSinkConfig{{Type: LogSinkConsole}}, c.GetLogSinks())
+	assert.Equal(t, SamplerAlways, c.GetTracingConfig().Sampler)
+}
+
+func TestNewConfig_ProductionProfileDefaults(t *testing.T) {
+	os.Clearenv()
+	t.Setenv(EnvEnvironment, "prod")
+	c := NewConfig()
+	assert.Equal(t, ProfileProduction, c.GetProfile())
+	assert.Empty(t, c.GetCORSAllowedOrigins())
+	assert.Equal(t, TracingConfig{Sampler: SamplerParentRatio, SampleRatio: productionSampleRatio, Exporter: TraceExporterOTLPGRPC}, c.GetTracingConfig())
+	assert.Equal(t, shippedLogSinks(), c.GetLogSinks())
+}
+
+func TestNewConfig_ProfileEnvOverridesEnvLabel(t *testing.T) {
+	t.Setenv(EnvEnvironment, "local-docker")
+	t.Setenv(EnvProfile, "staging")
+	c := NewConfig()
+	assert.Equal(t, ProfileStaging, c.GetProfile())
+	assert.Equal(t, "local-docker", c.GetEnv())
+}
+
+func TestLoad_ProfileOverlayFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "staging.yaml"), []byte("log:\n  level: warn\n"), 0o600))
+	t.Setenv(EnvConfigDir, dir)
+	t.Setenv(EnvEnvironment, "stg")
+	setJWTSecret(t)
+
+	c, err := Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "staging.yaml"), c.File)
+	assert.Equal(t, "warn", c.GetLogLevel())
+}
+
+func TestLoad_ProductionGuard_RejectsInsecureSettings(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		val  string
+	}{
+		{name: "WildcardCORS", key: EnvCORSAllowedOrigins, val: "*"},
+		{name: "DebugLogging", key: EnvLogLevel, val: "debug"},
+		{name: "PlainHTTPJWKS", key: EnvJWTJWKSURL, val: "http://issuer.example/jwks.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvConfigDir, t.TempDir())
+			t.Setenv(EnvEnvironment, "production")
+			setJWTSecret(t)
+			t.Setenv(tt.key, tt.val)
+
+			_, err := Load()
+
+			assert.ErrorIs(t, err, ErrInsecureConfig)
+		})
+	}
+}
+
+func TestLoad_ProductionDefaults_AreAccepted(t *testing.T) {
+	t.Setenv(EnvConfigDir, t.TempDir())
+	t.Setenv(EnvEnvironment, "production")
+	setJWTSecret(t)
+
+	_, err := Load()
+
+	assert.NoError(t, err)
+}
+
+func TestLoad_RepositoryProfileFiles_AreValid(t *testing.T) {
+	for _, profile := range []Profile{ProfileLocal, ProfileTest, ProfileStaging, ProfileProduction} {
+		t.Run(string(profile), func(t *testing.T) {
+			t.Setenv(EnvConfigDir, filepath.Join("..", "..", "..", DefaultConfigDir))
+			t.Setenv(EnvProfile, string(profile))
+			setJWTSecret(t)
+
+			c, err := Load()
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, c.File)
+		})
+	}
+}
+
+func TestLoad_ProductionGuard_RejectsShortAdminToken(t *testing.T) {
+	t.Setenv(EnvConfigDir, t.TempDir())
+	t.Setenv(EnvEnvironment, "production")
+	setJWTSecret(t)
+	t.Setenv(EnvAdminToken, "short")
+
+	_, err := Load()
+
+	assert.ErrorIs(t, err, ErrInsecureConfig)
+}
+
+// setJWTSecret configures an authenticator, which deployed profiles require.
+func setJWTSecret(t *testing.T) {
+	t.Helper()
+	t.Setenv(EnvJWTSecret, "profile-tests-secret-0123456789abcdef")
+}
+
+func TestLoad_DeployedProfiles_RequireAuthentication(t *testing.T) {
+	for _, profile := range []Profile{ProfileStaging, ProfileProduction} {
+		t.Run(string(profile), func(t *testing.T) {
+			t.Setenv(EnvConfigDir, t.TempDir())
+			t.Setenv(EnvProfile, string(profile))
+
+			_, err := Load()
+			assert.ErrorIs(t, err, ErrNoAuthentication)
+
+			t.Setenv(EnvAPIKeysRedis, "true")
+			_, err = Load()
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLoad_LocalAndTestProfiles_RunWithoutAuthentication(t *testing.T) {
+	for _, profile := range []Profile{ProfileLocal, ProfileTest} {
+		t.Run(string(profile), func(t *testing.T) {
+			t.Setenv(EnvConfigDir, t.TempDir())
+			t.Setenv(EnvProfile, string(profile))
+
+			_, err := Load()
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLoad_UnknownExplicitProfile_Rejected(t *testing.T) {
+	t.Setenv(EnvConfigDir, t.TempDir())
+	t.Setenv(EnvProfile, "prodution")
+
+	_, err := Load()
+
+	assert.ErrorIs(t, err, ErrUnknownProfile)
+}
+
+func TestParseProfile(t *testing.T) {
+	profile, err := ParseProfile("Production")
+	require.NoError(t, err)
+	assert.Equal(t, ProfileProduction, profile)
+
+	_, err = ParseProfile("prod")
+	assert.ErrorIs(t, err, ErrUnknownProfile)
+}
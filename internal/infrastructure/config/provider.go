@@ -8,11 +8,36 @@ type Provider interface {
 	GetServerReadTimeout() time.Duration
 	GetServerWriteTimeout() time.Duration
 	GetRedisHost() string
+	GetRedisConfig() RedisConfig
+	GetUserAPIConfig() UserAPIConfig
 	GetEnv() string
 	GetAppName() string
 	GetOTLPEndpoint() string
+	GetLogLevel() string
+	GetLogSamplingConfig() LogSamplingConfig
+	GetLogRedactionConfig() LogRedactionConfig
+	GetLogSinks() []LogSinkConfig
+	GetLogBodyConfig() LogBodyConfig
+	GetCORSAllowedOrigins() []string
+	GetLimitConfig() LimitConfig
+	GetProfile() Profile
+	GetTracingConfig() TracingConfig
+	GetAdminToken() string
+	GetHealthConfig() HealthConfig
+	GetStartupConfig() StartupConfig
+	GetTenancyConfig() TenancyConfig
+	// GetTenantLimitConfig returns the limit policies of tenant: the global ones with the
+	// overrides of the tenant applied.
+	GetTenantLimitConfig(tenant string) LimitConfig
+	GetAuthConfig() AuthConfig
+	// Subscribe registers fn to be called with the new configuration every time it is reloaded.
+	// Returning an error from fn rejects the update and rolls every subscriber back.
+	Subscribe(name string, fn Subscriber)
 }
 
+// Subscriber applies a freshly loaded configuration to a running component.
+type Subscriber func(cfg Provider) error
+
 var _ Provider = (*Config)(nil)
 
 func (c *Config) GetServerHost() string {
@@ -35,6 +60,22 @@ func (c *Config) GetRedisHost() string {
 	return c.Redis.Host
 }
 
+func (c *Config) GetRedisConfig() RedisConfig {
+	return c.Redis
+}
+
+func (c *Config) GetUserAPIConfig() UserAPIConfig {
+	return c.UserAPI
+}
+
+func (c *Config) GetHealthConfig() HealthConfig {
+	return c.Health
+}
+
+func (c *Config) GetStartupConfig() StartupConfig {
+	return c.Startup
+}
+
 func (c *Config) GetEnv() string {
 	return c.Env
 }
@@ -46,3 +87,62 @@ func (c *Config) GetAppName() string {
 func (c *Config) GetOTLPEndpoint() string {
 	return c.Server.OTLPEndpoint
 }
+
+func (c *Config) GetLogLevel() string {
+	return c.Log.Level
+}
+
+func (c *Config) GetLogSamplingConfig() LogSamplingConfig {
+	return c.Log.Sampling
+}
+
+func (c *Config) GetLogRedactionConfig() LogRedactionConfig {
+	return c.Log.Redaction
+}
+
+func (c *Config) GetLogSinks() []LogSinkConfig {
+	return c.Log.Sinks
+}
+
+func (c *Config) GetLogBodyConfig() LogBodyConfig {
+	return c.Log.Body
+}
+
+func (c *Config) GetCORSAllowedOrigins() []string {
+	return c.CORS.AllowedOrigins
+}
+
+func (c *Config) GetLimitConfig() LimitConfig {
+	return c.Limit
+}
+
+func (c *Config) GetTenancyConfig() TenancyConfig {
+	return c.Tenancy
+}
+
+func (c *Config) GetTenantLimitConfig(tenant string) LimitConfig {
+	override, ok := c.Tenancy.Tenants[tenant]
+	if !ok {
+		return c.Limit
+	}
+	return c.Limit.Override(override.Limit)
+}
+
+func (c *Config) GetAuthConfig() AuthConfig {
+	return c.Auth
+}
+
+func (c *Config) GetProfile() Profile {
+	return c.Profile
+}
+
+func (c *Config) GetTracingConfig() TracingConfig {
+	return c.Tracing
+}
+
+func (c *Config) GetAdminToken() string {
+	return c.Admin.Token
+}
+
+// Subscribe is a no-op: a plain Config never changes. Use a Watcher for reloadable configuration.
+func (c *Config) Subscribe(_ string, _ Subscriber) {}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// RedisConfig configures the Redis client. Zero timeouts use the client defaults, and
+// MaxRetries -1 disables retries.
+//
+// Mode selects the topology: a single node at Host, a Sentinel-monitored master named
+// MasterName discovered through the sentinels in Addrs, or a cluster seeded with Addrs.
+// Addrs defaults to Host.
+type RedisConfig struct {
+	Mode             string         `yaml:"mode"`
+	Host             string         `yaml:"host"`
+	Addrs            []string       `yaml:"addrs"`
+	MasterName       string         `yaml:"master_name"`
+	SentinelUsername string         `yaml:"sentinel_username"`
+	SentinelPassword string         `yaml:"sentinel_password"`
+	Username         string         `yaml:"username"`
+	Password         string         `yaml:"password"`
+	DB               int            `yaml:"db"`
+	TLS              RedisTLSConfig `yaml:"tls"`
+	DialTimeout      time.Duration  `yaml:"dial_timeout"`
+	ReadTimeout      time.Duration  `yaml:"read_timeout"`
+	WriteTimeout     time.Duration  `yaml:"write_timeout"`
+	PoolSize         int            `yaml:"pool_size"`
+	MinIdleConns     int            `yaml:"min_idle_conns"`
+	MaxRetries       int            `yaml:"max_retries"`
+	// SlowCommandThreshold logs the commands taking at least this long; zero disables it.
+	SlowCommandThreshold time.Duration `yaml:"slow_command_threshold"`
+}
+
+// Addresses returns the nodes to connect to: Addrs, or Host when Addrs is empty.
+func (r *RedisConfig) Addresses() []string {
+	if len(r.Addrs) > 0 {
+		return r.Addrs
+	}
+	if r.Host == EmptyString {
+		return nil
+	}
+	return []string{r.Host}
+}
+
+// RedisTLSConfig enables TLS to Redis. CAFile replaces the system roots, and CertFile and
+// KeyFile, set together, authenticate the client.
+type RedisTLSConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	CAFile     string `yaml:"ca_file"`
+	CertFile   string `yaml:"cert_file"`
+	KeyFile    string `yaml:"key_file"`
+	ServerName string `yaml:"server_name"`
+}
+
+func (r *RedisConfig) validate() error {
+	switch {
+	case r.DB < 0:
+		return fmt.Errorf("%w: redis db must not be negative", ErrInvalidConfig)
+	case r.DialTimeout < 0 || r.ReadTimeout < 0 || r.WriteTimeout < 0 || r.SlowCommandThreshold < 0:
+		return fmt.Errorf("%w: redis timeouts must not be negative", ErrInvalidConfig)
+	case r.MaxRetries < -1:
+		return fmt.Errorf("%w: redis max retries must be -1 (disabled) or more", ErrInvalidConfig)
+	}
+	if err := r.validateTopology(); err != nil {
+		return err
+	}
+	if err := r.validatePool(); err != nil {
+		return err
+	}
+	return r.TLS.validate()
+}
+
+func (r *RedisConfig) validateTopology() error {
+	if _, ok := redisModes[r.Mode]; !ok {
+		return fmt.Errorf("%w: unknown redis mode %q", ErrInvalidConfig, r.Mode)
+	}
+	if len(r.Addresses()) == 0 {
+		return fmt.Errorf("%w: redis host is required", ErrInvalidConfig)
+	}
+	if r.Mode == RedisModeSentinel && r.MasterName == EmptyString {
+		return fmt.Errorf("%w: redis sentinel mode requires a master name", ErrInvalidConfig)
+	}
+	if r.Mode == RedisModeCluster && r.DB != 0 {
+		return fmt.Errorf("%w: redis cluster mode only supports db 0", ErrInvalidConfig)
+	}
+	return nil
+}
+
+func (r *RedisConfig) validatePool() error {
+	if r.PoolSize <= 0 {
+		return fmt.Errorf("%w: redis pool size must be positive", ErrInvalidConfig)
+	}
+	if r.MinIdleConns < 0 || r.MinIdleConns > r.PoolSize {
+		return fmt.Errorf("%w: redis min idle connections must be between 0 and the pool size", ErrInvalidConfig)
+	}
+	return nil
+}
+
+func (t *RedisTLSConfig) validate() error {
+	if (t.CertFile == EmptyString) != (t.KeyFile == EmptyString) {
+		return fmt.Errorf("%w: redis TLS cert and key files must be set together", ErrInvalidConfig)
+	}
+	if !t.Enabled && (t.CAFile != EmptyString || t.CertFile != EmptyString) {
+		return fmt.Errorf("%w: redis TLS files are set but TLS is disabled", ErrInvalidConfig)
+	}
+	return nil
+}
+
+func (c *Config) applyRedisEnv() {
+	c.Redis.Mode = getEnv(EnvRedisMode, c.Redis.Mode)
+	c.Redis.Host = getEnv(EnvRedisHost, c.Redis.Host)
+	c.Redis.Addrs = getEnvAsSlice(EnvRedisAddrs, c.Redis.Addrs)
+	c.Redis.MasterName = getEnv(EnvRedisMasterName, c.Redis.MasterName)
+	c.Redis.SentinelUsername = getEnv(EnvRedisSentinelUsername, c.Redis.SentinelUsername)
+	c.Redis.SentinelPassword = getEnv(EnvRedisSentinelPassword, c.Redis.SentinelPassword)
+	c.Redis.Username = getEnv(EnvRedisUsername, c.Redis.Username)
+	c.Redis.Password = getEnv(EnvRedisPassword, c.Redis.Password)
+	c.Redis.DB = getEnvAsInt(EnvRedisDB, c.Redis.DB)
+	c.Redis.TLS.Enabled = getEnvAsBool(EnvRedisTLS, c.Redis.TLS.Enabled)
+	c.Redis.TLS.CAFile = getEnv(EnvRedisTLSCAFile, c.Redis.TLS.CAFile)
+	c.Redis.TLS.CertFile = getEnv(EnvRedisTLSCertFile, c.Redis.TLS.CertFile)
+	c.Redis.TLS.KeyFile = getEnv(EnvRedisTLSKeyFile, c.Redis.TLS.KeyFile)
+	c.Redis.TLS.ServerName = getEnv(EnvRedisTLSServerName, c.Redis.TLS.ServerName)
+	c.Redis.DialTimeout = getEnvAsDuration(EnvRedisDialTimeout, c.Redis.DialTimeout)
+	c.Redis.ReadTimeout = getEnvAsDuration(EnvRedisReadTimeout, c.Redis.ReadTimeout)
+	c.Redis.WriteTimeout = getEnvAsDuration(EnvRedisWriteTimeout, c.Redis.WriteTimeout)
+	c.Redis.PoolSize = getEnvAsInt(EnvRedisPoolSize, c.Redis.PoolSize)
+	c.Redis.MinIdleConns = getEnvAsInt(EnvRedisMinIdleConns, c.Redis.MinIdleConns)
+	c.Redis.MaxRetries = getEnvAsInt(EnvRedisMaxRetries, c.Redis.MaxRetries)
+	c.Redis.SlowCommandThreshold = getEnvAsDuration(EnvRedisSlowCommandThreshold, c.Redis.SlowCommandThreshold)
+}
+
+//nolint:gochecknoglobals // Read-only lookup table of accepted Redis modes.
+var redisModes = map[string]struct{}{
+	RedisModeStandalone: {},
+	RedisModeSentinel:   {},
+	RedisModeCluster:    {},
+}
+
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeSentinel   = "sentinel"
+	RedisModeCluster    = "cluster"
+)
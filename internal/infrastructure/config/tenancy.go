@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TenancyConfig resolves the tenant of each API request and holds the overrides of each
+// tenant. The tenant is read from Header, then from the request host through Hosts, and
+// is Default otherwise; requests left without a tenant are rejected.
+type TenancyConfig struct {
+	Header  string            `yaml:"header"`
+	Hosts   map[string]string `yaml:"hosts"`
+	Default string            `yaml:"default"`
+	// Strict rejects the tenants that are neither Default nor listed in Tenants.
+	Strict  bool                    `yaml:"strict"`
+	Tenants map[string]TenantConfig `yaml:"tenants"`
+}
+
+// TenantConfig overrides settings for a single tenant.
+type TenantConfig struct {
+	// Limit policies are added to the global ones, replacing those with the same name,
+	// and a non-empty DefaultPolicy replaces the global default policy.
+	Limit LimitConfig `yaml:"limit"`
+}
+
+// Known reports whether requests for tenant are accepted.
+func (t *TenancyConfig) Known(tenant string) bool {
+	if !t.Strict || tenant == t.Default {
+		return true
+	}
+	_, ok := t.Tenants[tenant]
+	return ok
+}
+
+// validateTenancy checks the tenant IDs and the limit policies each tenant ends up with.
+func (c *Config) validateTenancy() error {
+	if c.Tenancy.Header == EmptyString {
+		return fmt.Errorf("%w: tenant header is required", ErrInvalidConfig)
+	}
+	if c.Tenancy.Default != EmptyString && !ValidTenantID(c.Tenancy.Default) {
+		return fmt.Errorf("%w: invalid default tenant %q", ErrInvalidConfig, c.Tenancy.Default)
+	}
+	for host, tenant := range c.Tenancy.Hosts {
+		if !ValidTenantID(tenant) {
+			return fmt.Errorf("%w: invalid tenant %q for host %q", ErrInvalidConfig, tenant, host)
+		}
+	}
+	for tenant, override := range c.Tenancy.Tenants {
+		if !ValidTenantID(tenant) {
+			return fmt.Errorf("%w: invalid tenant %q", ErrInvalidConfig, tenant)
+		}
+		limit := c.Limit.Override(override.Limit)
+		if err := limit.validate(); err != nil {
+			return fmt.Errorf("tenant %q: %w", tenant, err)
+		}
+	}
+	return nil
+}
+
+// ValidTenantID reports whether id may name a tenant: 1 to 64 letters, digits, '-' or '_'.
+func ValidTenantID(id string) bool {
+	if id == EmptyString || len(id) > maxTenantIDLength {
+		return false
+	}
+	return strings.IndexFunc(id, invalidTenantRune) < 0
+}
+
+func invalidTenantRune(r rune) bool {
+	return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_'
+}
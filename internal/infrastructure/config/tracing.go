@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+)
+
+// TracingConfig selects how many traces are recorded and where they are exported.
+type TracingConfig struct {
+	Sampler     string  `yaml:"sampler"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+	// Exporter is TraceExporterOTLPGRPC, TraceExporterOTLPHTTP, TraceExporterStdout or
+	// TraceExporterNone. The OTLP exporters send to the OTLP endpoint.
+	Exporter string `yaml:"exporter"`
+	// TLS secures the OTLP connection; it is plaintext otherwise.
+	TLS bool `yaml:"tls"`
+	// Headers are sent with every OTLP export, e.g. to authenticate with a vendor.
+	Headers map[string]string `yaml:"headers"`
+}
+
+func (t *TracingConfig) validate() error {
+	if _, ok := samplers[t.Sampler]; !ok {
+		return fmt.Errorf("%w: unknown trace sampler %q", ErrInvalidConfig, t.Sampler)
+	}
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		return fmt.Errorf("%w: trace sample ratio must be between 0 and 1", ErrInvalidConfig)
+	}
+	if _, ok := traceExporters[t.Exporter]; !ok {
+		return fmt.Errorf("%w: unknown trace exporter %q", ErrInvalidConfig, t.Exporter)
+	}
+	return nil
+}
+
+//nolint:gochecknoglobals // Read-only lookup table of accepted trace samplers.
+var samplers = map[string]struct{}{
+	SamplerAlways:      {},
+	SamplerRatio:       {},
+	SamplerNever:       {},
+	SamplerParentRatio: {},
+}
+
+//nolint:gochecknoglobals // Read-only lookup table of accepted trace exporters.
+var traceExporters = map[string]struct{}{
+	TraceExporterOTLPGRPC: {},
+	TraceExporterOTLPHTTP: {},
+	TraceExporterStdout:   {},
+	TraceExporterNone:     {},
+}
+
+const (
+	SamplerAlways = "always"
+	SamplerRatio  = "ratio"
+	SamplerNever  = "never"
+	// SamplerParentRatio follows the sampling decision of the caller and samples the
+	// ratio of the traces that start here.
+	SamplerParentRatio = "parent_ratio"
+)
+
+const (
+	TraceExporterOTLPGRPC = "otlp_grpc"
+	TraceExporterOTLPHTTP = "otlp_http"
+	TraceExporterStdout   = "stdout"
+	TraceExporterNone     = "none"
+)
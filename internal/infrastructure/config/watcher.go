@@ -0,0 +1,261 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher is a Provider whose configuration can be reloaded at runtime.
+// Reads always see a complete snapshot; reloads swap the snapshot atomically.
+type Watcher struct {
+	current     atomic.Pointer[Config]
+	load        func() (*Config, error)
+	mu          sync.Mutex
+	subscribers []subscription
+}
+
+type subscription struct {
+	name string
+	fn   Subscriber
+}
+
+var _ Provider = (*Watcher)(nil)
+
+// NewWatcher loads the initial configuration and returns a reloadable provider for it.
+func NewWatcher() (*Watcher, error) {
+	return newWatcher(Load)
+}
+
+func newWatcher(load func() (*Config, error)) (*Watcher, error) {
+	cfg, err := load()
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{load: load}
+	w.current.Store(cfg)
+	return w, nil
+}
+
+// Current returns the active configuration snapshot.
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// Subscribe registers fn to be notified on every successful reload.
+func (w *Watcher) Subscribe(name string, fn Subscriber) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, subscription{name: name, fn: fn})
+}
+
+// Reload loads and validates a new configuration, swaps it in and notifies subscribers.
+// An invalid configuration is rejected before the swap; a subscriber error rolls the
+// swap back and re-applies the previous configuration to subscribers already notified.
+func (w *Watcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	next, err := w.load()
+	if err != nil {
+		return fmt.Errorf("reload rejected: %w", err)
+	}
+
+	prev := w.current.Swap(next)
+	for i, sub := range w.subscribers {
+		if err := sub.fn(next); err != nil {
+			w.current.Store(prev)
+			rollbackErr := w.notify(prev, w.subscribers[:i])
+			return errors.Join(fmt.Errorf("reload rejected by %s, rolled back: %w", sub.name, err), rollbackErr)
+		}
+	}
+	return nil
+}
+
+func (w *Watcher) notify(cfg *Config, subscribers []subscription) error {
+	var errs []error
+	for _, sub := range subscribers {
+		if err := sub.fn(cfg); err != nil {
+			errs = append(errs, fmt.Errorf("rollback of %s: %w", sub.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Watch reloads the configuration on SIGHUP and whenever the configuration file changes,
+// until ctx is canceled. onReload is called after every attempt with its outcome.
+func (w *Watcher) Watch(ctx context.Context, onReload func(err error)) error {
+	var events chan fsnotify.Event
+	var fileWatcher *fsnotify.Watcher
+	if path := w.Current().File; path != EmptyString {
+		var err error
+		if fileWatcher, err = fsnotify.NewWatcher(); err != nil {
+			return fmt.Errorf("watch config file: %w", err)
+		}
+		// Watch the directory rather than the file so editors' rename-on-save and
+		// Kubernetes ConfigMap symlink swaps are both picked up.
+		if err := fileWatcher.Add(filepath.Dir(path)); err != nil {
+			_ = fileWatcher.Close()
+			return fmt.Errorf("watch config file: %w", err)
+		}
+		events = fileWatcher.Events
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(signals)
+		if fileWatcher != nil {
+			defer func() { _ = fileWatcher.Close() }()
+		}
+		w.loop(ctx, signals, events, onReload)
+	}()
+	return nil
+}
+
+func (w *Watcher) loop(ctx context.Context, signals <-chan os.Signal, events <-chan fsnotify.Event, onReload func(err error)) {
+	debounce := time.NewTimer(0)
+	<-debounce.C
+	for {
+		select {
+		case <-ctx.Done():
+			debounce.Stop()
+			return
+		case <-signals:
+			onReload(w.Reload())
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if w.affectsConfigFile(event) {
+				debounce.Reset(reloadDebounce)
+			}
+		case <-debounce.C:
+			onReload(w.Reload())
+		}
+	}
+}
+
+func (w *Watcher) affectsConfigFile(event fsnotify.Event) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	name := filepath.Base(event.Name)
+	return name == filepath.Base(w.Current().File) || name == configMapDataDir
+}
+
+func (w *Watcher) GetServerHost() string {
+	return w.Current().GetServerHost()
+}
+
+func (w *Watcher) GetServerPort() string {
+	return w.Current().GetServerPort()
+}
+
+func (w *Watcher) GetServerReadTimeout() time.Duration {
+	return w.Current().GetServerReadTimeout()
+}
+
+func (w *Watcher) GetServerWriteTimeout() time.Duration {
+	return w.Current().GetServerWriteTimeout()
+}
+
+func (w *Watcher) GetRedisHost() string {
+	return w.Current().GetRedisHost()
+}
+
+func (w *Watcher) GetEnv() string {
+	return w.Current().GetEnv()
+}
+
+func (w *Watcher) GetAppName() string {
+	return w.Current().GetAppName()
+}
+
+func (w *Watcher) GetOTLPEndpoint() string {
+	return w.Current().GetOTLPEndpoint()
+}
+
+func (w *Watcher) GetLogLevel() string {
+	return w.Current().GetLogLevel()
+}
+
+func (w *Watcher) GetLogSamplingConfig() LogSamplingConfig {
+	return w.Current().GetLogSamplingConfig()
+}
+
+func (w *Watcher) GetLogRedactionConfig() LogRedactionConfig {
+	return w.Current().GetLogRedactionConfig()
+}
+
+func (w *Watcher) GetLogSinks() []LogSinkConfig {
+	return w.Current().GetLogSinks()
+}
+
+func (w *Watcher) GetLogBodyConfig() LogBodyConfig {
+	return w.Current().GetLogBodyConfig()
+}
+
+func (w *Watcher) GetRedisConfig() RedisConfig {
+	return w.Current().GetRedisConfig()
+}
+
+func (w *Watcher) GetUserAPIConfig() UserAPIConfig {
+	return w.Current().GetUserAPIConfig()
+}
+
+func (w *Watcher) GetHealthConfig() HealthConfig {
+	return w.Current().GetHealthConfig()
+}
+
+func (w *Watcher) GetStartupConfig() StartupConfig {
+	return w.Current().GetStartupConfig()
+}
+
+func (w *Watcher) GetTenancyConfig() TenancyConfig {
+	return w.Current().GetTenancyConfig()
+}
+
+func (w *Watcher) GetTenantLimitConfig(tenant string) LimitConfig {
+	return w.Current().GetTenantLimitConfig(tenant)
+}
+
+func (w *Watcher) GetAuthConfig() AuthConfig {
+	return w.Current().GetAuthConfig()
+}
+
+func (w *Watcher) GetCORSAllowedOrigins() []string {
+	return w.Current().GetCORSAllowedOrigins()
+}
+
+func (w *Watcher) GetLimitConfig() LimitConfig {
+	return w.Current().GetLimitConfig()
+}
+
+func (w *Watcher) GetProfile() Profile {
+	return w.Current().GetProfile()
+}
+
+func (w *Watcher) GetTracingConfig() TracingConfig {
+	return w.Current().GetTracingConfig()
+}
+
+func (w *Watcher) GetAdminToken() string {
+	return w.Current().GetAdminToken()
+}
+
+const (
+	reloadDebounce   = 200 * time.Millisecond
+	configMapDataDir = "..data"
+)
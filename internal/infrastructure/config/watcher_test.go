@@ -0,0 +1,149 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, path, level string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: "+level+"\n"), 0o600))
+}
+
+func newFileWatcher(t *testing.T, level string) (*Watcher, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, level)
+	t.Setenv(EnvConfigFile, path)
+	t.Setenv(EnvLogLevel, "")
+
+	w, err := NewWatcher()
+	require.NoError(t, err)
+	return w, path
+}
+
+func TestNewWatcher_LoadsInitialConfig(t *testing.T) {
+	w, _ := newFileWatcher(t, "warn")
+
+	assert.Equal(t, "warn", w.GetLogLevel())
+}
+
+func TestNewWatcher_InvalidConfig_ReturnsError(t *testing.T) {
+	t.Setenv(EnvLogLevel, "loud")
+
+	_, err := NewWatcher()
+
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestWatcher_Reload_SwapsConfigAndNotifiesSubscribers(t *testing.T) {
+	w, path := newFileWatcher(t, "info")
+	var notified string
+	w.Subscribe("test", func(cfg Provider) error {
+		notified = cfg.GetLogLevel()
+		return nil
+	})
+	writeConfigFile(t, path, "debug")
+
+	require.NoError(t, w.Reload())
+
+	assert.Equal(t, "debug", w.GetLogLevel())
+	assert.Equal(t, "debug", notified)
+}
+
+func TestWatcher_Reload_InvalidConfig_KeepsCurrent(t *testing.T) {
+	w, path := newFileWatcher(t, "info")
+	called := false
+	w.Subscribe("test", func(Provider) error {
+		called = true
+		return nil
+	})
+	writeConfigFile(t, path, "loud")
+
+	err := w.Reload()
+
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Equal(t, "info", w.GetLogLevel())
+	assert.False(t, called)
+}
+
+func TestWatcher_Reload_SubscriberError_RollsBack(t *testing.T) {
+	w, path := newFileWatcher(t, "info")
+	var applied []string
+	w.Subscribe("first", func(cfg Provider) error {
+		applied = append(applied, cfg.GetLogLevel())
+		return nil
+	})
+	w.Subscribe("second", func(Provider) error {
+		return errors.New("cannot apply")
+	})
+	writeConfigFile(t, path, "debug")
+
+	err := w.Reload()
+
+	require.Error(t, err)
+	assert.Equal(t, "info", w.GetLogLevel())
+	assert.Equal(t, []string{"debug", "info"}, applied)
+}
+
+func TestWatcher_Watch_ReloadsOnFileChange(t *testing.T) {
+	w, path := newFileWatcher(t, "info")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloaded := make(chan error, 1)
+	require.NoError(t, w.Watch(ctx, func(err error) { reloaded <- err }))
+
+	writeConfigFile(t, path, "error")
+
+	select {
+	case err := <-reloaded:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("config file change was not picked up")
+	}
+	assert.Equal(t, "error", w.GetLogLevel())
+}
+
+func TestWatcher_Watch_ReloadsOnSIGHUP(t *testing.T) {
+	var level atomic.Value
+	level.Store("info")
+	w, err := newWatcher(func() (*Config, error) {
+		cfg := NewConfig()
+		cfg.Log.Level = level.Load().(string)
+		return cfg, nil
+	})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloaded := make(chan error, 1)
+	require.NoError(t, w.Watch(ctx, func(err error) { reloaded <- err }))
+	level.Store("warn")
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	select {
+	case err := <-reloaded:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("SIGHUP did not trigger a reload")
+	}
+	assert.Equal(t, "warn", w.GetLogLevel())
+}
+
+func TestConfig_Subscribe_IsNoop(t *testing.T) {
+	c := NewConfig()
+	level := c.GetLogLevel()
+
+	c.Subscribe("test", func(Provider) error { return errors.New("never called") })
+
+	assert.Equal(t, level, c.GetLogLevel())
+}
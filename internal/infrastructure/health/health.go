@@ -0,0 +1,183 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Check is a dependency probed by the readiness endpoint.
+type Check struct {
+	Name string
+	// Critical checks make the service unready when they fail; the others only degrade it.
+	Critical bool
+	// Timeout bounds each probe; zero uses the registry timeout.
+	Timeout time.Duration
+	// Probe returns nil when the dependency is usable.
+	Probe func(ctx context.Context) error
+}
+
+// Config bounds the probes run by a Registry. Results are reused for CacheTTL.
+type Config struct {
+	Timeout  time.Duration
+	CacheTTL time.Duration
+}
+
+// Result is the outcome of the last run of a check.
+type Result struct {
+	Status    string    `json:"status"`
+	Critical  bool      `json:"critical"`
+	LatencyMS float64   `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report aggregates the results of every registered check. Status is StatusOK when all
+// checks pass, StatusDegraded when only non-critical ones fail and StatusDown otherwise.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Ready reports whether every critical check passed.
+func (r *Report) Ready() bool {
+	return r.Status != StatusDown
+}
+
+// Registry runs the registered checks concurrently, each with its own timeout, and
+// caches their results. It is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	config  Config
+	checks  []*entry
+	now     func() time.Time
+	startup startupState
+}
+
+// entry serializes the probes of a check so concurrent reports share a single run.
+type entry struct {
+	mu      sync.Mutex
+	check   Check
+	result  Result
+	expires time.Time
+}
+
+func NewRegistry(cfg Config) *Registry {
+	return &Registry{config: cfg, now: time.Now}
+}
+
+// Register adds check, replacing any check registered under the same name.
+func (r *Registry) Register(check Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.checks {
+		if existing.check.Name == check.Name {
+			r.checks[i] = &entry{check: check}
+			return
+		}
+	}
+	r.checks = append(r.checks, &entry{check: check})
+}
+
+// Report runs the checks whose cached result has expired and returns every result.
+func (r *Registry) Report(ctx context.Context) Report {
+	r.mu.RLock()
+	entries := make([]*entry, len(r.checks))
+	copy(entries, r.checks)
+	r.mu.RUnlock()
+
+	results := make([]Result, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.result(ctx, e)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(entries))}
+	for i, e := range entries {
+		report.Checks[e.check.Name] = results[i]
+		if results[i].Status == StatusOK {
+			continue
+		}
+		if e.check.Critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusOK {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+func (r *Registry) result(ctx context.Context, e *entry) Result {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if r.now().Before(e.expires) {
+		return e.result
+	}
+	return r.refresh(ctx, e)
+}
+
+// refresh runs the check of e and caches its result. It must be called with e.mu held.
+func (r *Registry) refresh(ctx context.Context, e *entry) Result {
+	e.result = r.run(ctx, e.check)
+	e.expires = e.result.CheckedAt.Add(r.config.CacheTTL)
+	return e.result
+}
+
+// run probes check. The probe outlives the caller's cancellation, as its result is
+// cached for other callers, but not its timeout: a probe ignoring its context is
+// abandoned when the timeout passes.
+func (r *Registry) run(ctx context.Context, check Check) Result {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = r.config.Timeout
+	}
+	probeCtx := context.WithoutCancel(ctx)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		probeCtx, cancel = context.WithTimeout(probeCtx, timeout)
+		defer cancel()
+	}
+
+	start := r.now()
+	done := make(chan error, 1)
+	go func() { done <- probe(probeCtx, check) }()
+	var err error
+	select {
+	case err = <-done:
+	case <-probeCtx.Done():
+		err = probeCtx.Err()
+	}
+
+	result := Result{
+		Status:    StatusOK,
+		Critical:  check.Critical,
+		LatencyMS: float64(r.now().Sub(start).Microseconds()) / float64(time.Millisecond/time.Microsecond),
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func probe(ctx context.Context, check Check) error {
+	if check.Probe == nil {
+		return ErrNoProbe
+	}
+	return check.Probe(ctx)
+}
+
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+var ErrNoProbe = errors.New("health check has no probe")
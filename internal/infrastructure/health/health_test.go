@@ -0,0 +1,107 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func passing(context.Context) error { return nil }
+
+func failing(context.Context) error { return assert.AnError }
+
+func TestRegistry_Report_AllPassing_OK(t *testing.T) {
+	registry := NewRegistry(Config{Timeout: time.Second})
+	registry.Register(Check{Name: "redis", Critical: true, Probe: passing})
+	registry.Register(Check{Name: "tracer", Probe: passing})
+
+	report := registry.Report(context.Background())
+
+	assert.Equal(t, StatusOK, report.Status)
+	assert.True(t, report.Ready())
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, StatusOK, report.Checks["redis"].Status)
+	assert.True(t, report.Checks["redis"].Critical)
+}
+
+func TestRegistry_Report_NonCriticalFailure_Degraded(t *testing.T) {
+	registry := NewRegistry(Config{Timeout: time.Second})
+	registry.Register(Check{Name: "redis", Critical: true, Probe: passing})
+	registry.Register(Check{Name: "tracer", Probe: failing})
+
+	report := registry.Report(context.Background())
+
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.True(t, report.Ready())
+	assert.Equal(t, StatusDown, report.Checks["tracer"].Status)
+	assert.Equal(t, assert.AnError.Error(), report.Checks["tracer"].Error)
+}
+
+func TestRegistry_Report_CriticalFailure_Down(t *testing.T) {
+	registry := NewRegistry(Config{Timeout: time.Second})
+	registry.Register(Check{Name: "redis", Critical: true, Probe: failing})
+	registry.Register(Check{Name: "tracer", Probe: failing})
+
+	report := registry.Report(context.Background())
+
+	assert.Equal(t, StatusDown, report.Status)
+	assert.False(t, report.Ready())
+}
+
+func TestRegistry_Report_SlowProbe_TimesOut(t *testing.T) {
+	registry := NewRegistry(Config{Timeout: time.Hour})
+	block := make(chan struct{})
+	defer close(block)
+	registry.Register(Check{Name: "stuck", Critical: true, Timeout: 10 * time.Millisecond, Probe: func(context.Context) error {
+		<-block
+		return nil
+	}})
+
+	report := registry.Report(context.Background())
+
+	assert.Equal(t, StatusDown, report.Checks["stuck"].Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["stuck"].Error)
+}
+
+func TestRegistry_Report_CachesResults(t *testing.T) {
+	registry := NewRegistry(Config{Timeout: time.Second, CacheTTL: time.Minute})
+	now := time.Now()
+	registry.now = func() time.Time { return now }
+	var calls atomic.Int32
+	registry.Register(Check{Name: "redis", Probe: func(context.Context) error {
+		calls.Add(1)
+		return nil
+	}})
+
+	registry.Report(context.Background())
+	registry.Report(context.Background())
+	assert.Equal(t, int32(1), calls.Load())
+
+	now = now.Add(time.Minute)
+	registry.Report(context.Background())
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRegistry_Register_ReplacesSameName(t *testing.T) {
+	registry := NewRegistry(Config{})
+	registry.Register(Check{Name: "redis", Probe: failing})
+	registry.Register(Check{Name: "redis", Probe: passing})
+
+	report := registry.Report(context.Background())
+
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, StatusOK, report.Checks["redis"].Status)
+}
+
+func TestRegistry_Report_NoProbe_Fails(t *testing.T) {
+	registry := NewRegistry(Config{})
+	registry.Register(Check{Name: "broken", Critical: true})
+
+	report := registry.Report(context.Background())
+
+	assert.Equal(t, ErrNoProbe.Error(), report.Checks["broken"].Error)
+}
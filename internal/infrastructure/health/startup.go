@@ -0,0 +1,197 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-service-template/internal/infrastructure/logger"
+)
+
+// StartupConfig bounds the startup phase run by Registry.Start.
+type StartupConfig struct {
+	// Timeout ends the startup phase even if some dependencies are still unavailable.
+	Timeout time.Duration
+	// InitialBackoff is the wait after the first failed attempt. It doubles after every
+	// failure up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Dependency is the connection progress of a critical check.
+type Dependency struct {
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	Error       string     `json:"error,omitempty"`
+	ConnectedAt *time.Time `json:"connected_at,omitempty"`
+}
+
+// StartupReport is StatusStarting until every critical dependency is connected or the
+// startup timeout passes, and StatusStarted after.
+type StartupReport struct {
+	Status       string                `json:"status"`
+	Dependencies map[string]Dependency `json:"dependencies"`
+}
+
+// Started reports whether the startup phase is over.
+func (r *StartupReport) Started() bool {
+	return r.Status == StatusStarted
+}
+
+type startupState struct {
+	mu           sync.Mutex
+	ended        bool
+	dependencies map[string]*Dependency
+}
+
+// Start begins the startup phase in the background: the probe of every critical check
+// is retried with exponential backoff until it passes. Dependencies still unavailable
+// when cfg.Timeout passes keep being retried until they connect or ctx is done. Checks
+// registered after Start are not waited for.
+func (r *Registry) Start(ctx context.Context, cfg StartupConfig) {
+	r.mu.RLock()
+	var critical []*entry
+	for _, e := range r.checks {
+		if e.check.Critical {
+			critical = append(critical, e)
+		}
+	}
+	r.mu.RUnlock()
+
+	r.startup.mu.Lock()
+	if r.startup.dependencies != nil {
+		r.startup.mu.Unlock()
+		return
+	}
+	r.startup.dependencies = make(map[string]*Dependency, len(critical))
+	for _, e := range critical {
+		r.startup.dependencies[e.check.Name] = &Dependency{Status: DependencyConnecting}
+	}
+	r.startup.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, e := range critical {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.connect(ctx, e, cfg)
+		}()
+	}
+	connected := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(connected)
+	}()
+	go func() {
+		timer := time.NewTimer(cfg.Timeout)
+		defer timer.Stop()
+		select {
+		case <-connected:
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+		r.endStartup(ctx)
+	}()
+}
+
+// StartupReport returns the progress of the startup phase.
+func (r *Registry) StartupReport() StartupReport {
+	r.startup.mu.Lock()
+	defer r.startup.mu.Unlock()
+	report := StartupReport{Status: StatusStarting, Dependencies: make(map[string]Dependency, len(r.startup.dependencies))}
+	if r.startup.ended {
+		report.Status = StatusStarted
+	}
+	for name, dependency := range r.startup.dependencies {
+		report.Dependencies[name] = *dependency
+	}
+	return report
+}
+
+// connect probes e until it passes, bypassing and refreshing the cached result so the
+// readiness probe sees the dependency as soon as it connects.
+func (r *Registry) connect(ctx context.Context, e *entry, cfg StartupConfig) {
+	backoff := cfg.InitialBackoff
+	for {
+		e.mu.Lock()
+		result := r.refresh(ctx, e)
+		e.mu.Unlock()
+
+		if r.recordAttempt(ctx, e.check.Name, result, backoff) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*backoffFactor, cfg.MaxBackoff)
+	}
+}
+
+// recordAttempt updates the dependency after an attempt and reports whether it connected.
+func (r *Registry) recordAttempt(ctx context.Context, name string, result Result, backoff time.Duration) bool {
+	r.startup.mu.Lock()
+	defer r.startup.mu.Unlock()
+	dependency := r.startup.dependencies[name]
+	dependency.Attempts++
+	dependency.Error = result.Error
+	if result.Status == StatusOK {
+		dependency.Status = DependencyConnected
+		connectedAt := result.CheckedAt
+		dependency.ConnectedAt = &connectedAt
+		logger.Info(ctx, "Dependency connected",
+			logger.String(fieldDependency, name),
+			logger.Int(fieldAttempts, dependency.Attempts),
+		)
+		return true
+	}
+
+	fields := []logger.Field{
+		logger.String(fieldDependency, name),
+		logger.Int(fieldAttempts, dependency.Attempts),
+		logger.String(logger.FieldError, result.Error),
+		logger.String(fieldRetryIn, backoff.String()),
+	}
+	if r.startup.ended {
+		logger.Debug(ctx, "Dependency still unavailable, reconnecting", fields...)
+	} else {
+		logger.Warn(ctx, "Dependency unavailable, retrying", fields...)
+	}
+	return false
+}
+
+func (r *Registry) endStartup(ctx context.Context) {
+	r.startup.mu.Lock()
+	defer r.startup.mu.Unlock()
+	r.startup.ended = true
+	var unavailable []string
+	for name, dependency := range r.startup.dependencies {
+		if dependency.Status != DependencyConnected {
+			dependency.Status = DependencyReconnecting
+			unavailable = append(unavailable, name)
+		}
+	}
+	if len(unavailable) > 0 {
+		logger.Error(ctx, "Startup ended with unavailable dependencies, reconnecting in the background",
+			logger.Any(fieldDependencies, unavailable),
+		)
+		return
+	}
+	logger.Info(ctx, "Startup complete")
+}
+
+const (
+	StatusStarting = "starting"
+	StatusStarted  = "started"
+
+	DependencyConnecting   = "connecting"
+	DependencyConnected    = "connected"
+	DependencyReconnecting = "reconnecting"
+
+	backoffFactor     = 2
+	fieldDependency   = "dependency"
+	fieldDependencies = "dependencies"
+	fieldAttempts     = "attempts"
+	fieldRetryIn      = "retry_in"
+)
@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingTimes returns a probe failing n times before passing.
+func failingTimes(n int32) func(context.Context) error {
+	var calls atomic.Int32
+	return func(context.Context) error {
+		if calls.Add(1) <= n {
+			return assert.AnError
+		}
+		return nil
+	}
+}
+
+var fastStartup = StartupConfig{Timeout: time.Minute, InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+
+func TestRegistry_StartupReport_BeforeStart_Starting(t *testing.T) {
+	registry := NewRegistry(Config{})
+
+	report := registry.StartupReport()
+
+	assert.Equal(t, StatusStarting, report.Status)
+	assert.False(t, report.Started())
+}
+
+func TestRegistry_Start_RetriesUntilConnected(t *testing.T) {
+	registry := NewRegistry(Config{Timeout: time.Second})
+	registry.Register(Check{Name: "redis", Critical: true, Probe: failingTimes(2)})
+	registry.Register(Check{Name: "tracer", Probe: failing})
+
+	registry.Start(context.Background(), fastStartup)
+
+	require.Eventually(t, func() bool {
+		report := registry.StartupReport()
+		return report.Started()
+	}, time.Second, time.Millisecond)
+	report := registry.StartupReport()
+	require.Len(t, report.Dependencies, 1, "only critical checks are waited for")
+	assert.Equal(t, DependencyConnected, report.Dependencies["redis"].Status)
+	assert.Equal(t, 3, report.Dependencies["redis"].Attempts)
+	assert.NotNil(t, report.Dependencies["redis"].ConnectedAt)
+	assert.Equal(t, StatusOK, registry.Report(context.Background()).Checks["redis"].Status)
+}
+
+func TestRegistry_Start_TimeoutKeepsReconnecting(t *testing.T) {
+	registry := NewRegistry(Config{Timeout: time.Second})
+	var available atomic.Bool
+	registry.Register(Check{Name: "redis", Critical: true, Probe: func(context.Context) error {
+		if available.Load() {
+			return nil
+		}
+		return assert.AnError
+	}})
+	cfg := fastStartup
+	cfg.Timeout = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	registry.Start(ctx, cfg)
+
+	require.Eventually(t, func() bool {
+		report := registry.StartupReport()
+		return report.Started()
+	}, time.Second, time.Millisecond)
+	report := registry.StartupReport()
+	assert.Equal(t, DependencyReconnecting, report.Dependencies["redis"].Status)
+	assert.Equal(t, assert.AnError.Error(), report.Dependencies["redis"].Error)
+
+	available.Store(true)
+	assert.Eventually(t, func() bool {
+		return registry.StartupReport().Dependencies["redis"].Status == DependencyConnected
+	}, time.Second, time.Millisecond)
+}
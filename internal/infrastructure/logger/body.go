@@ -0,0 +1,144 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyCaptureConfig limits what BodyCaptureMiddleware logs for a request.
+type BodyCaptureConfig struct {
+	// MaxBytes caps each captured body; longer bodies are truncated.
+	MaxBytes int
+	// Headers lists the request and response headers to log.
+	Headers []string
+}
+
+// BodyCaptureMiddleware logs the request and response bodies and selected headers of
+// the requests for which capture returns true. Bodies are truncated to MaxBytes, run
+// through the logger's redaction, and omitted for binary content types. It must run
+// after LoggingMiddleware so the entry carries the request's log context.
+func BodyCaptureMiddleware(capture func(c *gin.Context) (BodyCaptureConfig, bool)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg, ok := capture(c)
+		if !ok || cfg.MaxBytes <= 0 {
+			c.Next()
+			return
+		}
+
+		requestBody, requestTruncated := captureRequestBody(c.Request, cfg.MaxBytes)
+		writer := &bodyCaptureWriter{ResponseWriter: c.Writer, limit: cfg.MaxBytes}
+		c.Writer = writer
+
+		c.Next()
+
+		fields := []Field{
+			Any(FieldRequestHeaders, selectHeaders(c.Request.Header, cfg.Headers)),
+			Any(FieldResponseHeaders, selectHeaders(writer.Header(), cfg.Headers)),
+		}
+		fields = append(fields, bodyFields(FieldRequestBody, c.Request.Header.Get(contentTypeHeader), requestBody, requestTruncated)...)
+		fields = append(fields, bodyFields(FieldResponseBody, writer.Header().Get(contentTypeHeader), writer.body.Bytes(), writer.truncated)...)
+		GetGlobalLogger().Info(GetLogContext(c), "Request bodies captured", fields...)
+	}
+}
+
+// captureRequestBody reads up to limit bytes of the body and puts them back in front of
+// the unread remainder, so handlers still see the whole body.
+func captureRequestBody(request *http.Request, limit int) ([]byte, bool) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, false
+	}
+	prefix, err := io.ReadAll(io.LimitReader(request.Body, int64(limit)+1))
+	request.Body = &replayBody{Reader: io.MultiReader(bytes.NewReader(prefix), request.Body), Closer: request.Body}
+	if err != nil {
+		return nil, false
+	}
+	if len(prefix) > limit {
+		return prefix[:limit], true
+	}
+	return prefix, false
+}
+
+func bodyFields(key, contentType string, body []byte, truncated bool) []Field {
+	if len(body) == 0 {
+		return nil
+	}
+	if !textContentType(contentType) {
+		return []Field{String(key+omittedSuffix, contentType)}
+	}
+	fields := []Field{Body(key, contentType, body)}
+	if truncated {
+		fields = append(fields, Bool(key+truncatedSuffix, true))
+	}
+	return fields
+}
+
+// textContentType reports whether a body of this type is safe and useful to log.
+func textContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "+json") ||
+		strings.HasSuffix(mediaType, "+xml") ||
+		mediaType == gin.MIMEJSON ||
+		mediaType == gin.MIMEXML ||
+		mediaType == gin.MIMEPOSTForm
+}
+
+func selectHeaders(header http.Header, names []string) map[string]string {
+	selected := make(map[string]string, len(names))
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			selected[name] = value
+		}
+	}
+	return selected
+}
+
+type replayBody struct {
+	io.Reader
+	io.Closer
+}
+
+// bodyCaptureWriter keeps the first limit bytes written to the response.
+type bodyCaptureWriter struct {
+	gin.ResponseWriter
+	limit     int
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (w *bodyCaptureWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyCaptureWriter) WriteString(data string) (int, error) {
+	w.capture([]byte(data))
+	return w.ResponseWriter.WriteString(data)
+}
+
+func (w *bodyCaptureWriter) capture(data []byte) {
+	remaining := w.limit - w.body.Len()
+	if len(data) > remaining {
+		data = data[:max(remaining, 0)]
+		w.truncated = true
+	}
+	w.body.Write(data)
+}
+
+const (
+	FieldRequestBody     = "request_body"
+	FieldResponseBody    = "response_body"
+	FieldRequestHeaders  = "request_headers"
+	FieldResponseHeaders = "response_headers"
+	contentTypeHeader    = "Content-Type"
+	omittedSuffix        = "_omitted"
+	truncatedSuffix      = "_truncated"
+)
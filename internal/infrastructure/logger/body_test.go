@@ -0,0 +1,149 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// observeGlobalLogger sends the global logger's entries to an observer for the test.
+func observeGlobalLogger(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+	global, ok := GetGlobalLogger().(*zapLogger)
+	require.True(t, ok)
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := global.sinks.Swap(&sinkSet{logger: zap.New(core)})
+	t.Cleanup(func() { global.sinks.Store(previous) })
+	return logs
+}
+
+func newBodyCaptureRouter(cfg BodyCaptureConfig, capture bool, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(LoggingMiddleware())
+	r.Use(BodyCaptureMiddleware(func(*gin.Context) (BodyCaptureConfig, bool) { return cfg, capture }))
+	r.POST("/echo", handler)
+	return r
+}
+
+func capturedEntry(t *testing.T, logs *observer.ObservedLogs) map[string]interface{} {
+	t.Helper()
+	entries := logs.FilterMessage("Request bodies captured").All()
+	require.Len(t, entries, 1)
+	return entries[0].ContextMap()
+}
+
+func TestBodyCaptureMiddleware_LogsRedactedBodiesAndHeaders(t *testing.T) {
+	logs := observeGlobalLogger(t)
+	r := newBodyCaptureRouter(BodyCaptureConfig{MaxBytes: 1024, Headers: []string{"Content-Type", "Authorization"}}, true,
+		func(c *gin.Context) {
+			body, err := io.ReadAll(c.Request.Body)
+			require.NoError(t, err)
+			c.Data(http.StatusOK, gin.MIMEJSON, body)
+		})
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"email":"alice@example.com","password":"hunter2","name":"Alice"}`))
+	req.Header.Set("Content-Type", gin.MIMEJSON)
+	req.Header.Set("Authorization", "Bearer abc")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Contains(t, w.Body.String(), "hunter2", "the handler sees the original body")
+	fields := capturedEntry(t, logs)
+	assert.Equal(t, `{"email":"a***@example.com","password":"[REDACTED]","name":"Alice"}`, fields[FieldRequestBody])
+	assert.Equal(t, fields[FieldRequestBody], fields[FieldResponseBody])
+	assert.Equal(t, map[string]interface{}{"Content-Type": gin.MIMEJSON, "Authorization": redactedPlaceholder}, fields[FieldRequestHeaders])
+}
+
+func TestBodyCaptureMiddleware_TruncatesBodies(t *testing.T) {
+	logs := observeGlobalLogger(t)
+	payload := strings.Repeat("a", 100)
+	r := newBodyCaptureRouter(BodyCaptureConfig{MaxBytes: 10}, true, func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		assert.Len(t, body, 100)
+		c.String(http.StatusOK, string(body))
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "text/plain")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, payload, w.Body.String())
+	fields := capturedEntry(t, logs)
+	assert.Equal(t, payload[:10], fields[FieldRequestBody])
+	assert.Equal(t, true, fields[FieldRequestBody+truncatedSuffix])
+	assert.Equal(t, payload[:10], fields[FieldResponseBody])
+	assert.Equal(t, true, fields[FieldResponseBody+truncatedSuffix])
+}
+
+func TestBodyCaptureMiddleware_SkipsBinaryBodies(t *testing.T) {
+	logs := observeGlobalLogger(t)
+	r := newBodyCaptureRouter(BodyCaptureConfig{MaxBytes: 1024}, true, func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", []byte{0x89, 'P', 'N', 'G'})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader("\x00\x01"))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	fields := capturedEntry(t, logs)
+	assert.NotContains(t, fields, FieldRequestBody)
+	assert.NotContains(t, fields, FieldResponseBody)
+	assert.Equal(t, "application/octet-stream", fields[FieldRequestBody+omittedSuffix])
+	assert.Equal(t, "image/png", fields[FieldResponseBody+omittedSuffix])
+}
+
+func TestBodyCaptureMiddleware_NeverLogsIssuedAPIKeys(t *testing.T) {
+	const secret = "gst_ci_4f9c2b7e1d8a6035"
+	logs := observeGlobalLogger(t)
+	r := newBodyCaptureRouter(BodyCaptureConfig{MaxBytes: 1024}, true, func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"key": secret, "api_key": gin.H{"id": "ci", "tenant": "acme"}})
+	})
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"id":"ci","tenant":"acme"}`))
+	req.Header.Set("Content-Type", gin.MIMEJSON)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Contains(t, w.Body.String(), secret, "the client receives the key")
+	require.NotEmpty(t, logs.All())
+	for _, entry := range logs.All() {
+		for key, value := range entry.ContextMap() {
+			assert.NotContains(t, fmt.Sprint(value), secret, "field %s of %q", key, entry.Message)
+		}
+	}
+	assert.Contains(t, capturedEntry(t, logs)[FieldResponseBody], `"key":"[REDACTED]"`)
+}
+
+func TestBodyCaptureMiddleware_Disabled(t *testing.T) {
+	logs := observeGlobalLogger(t)
+	r := newBodyCaptureRouter(BodyCaptureConfig{MaxBytes: 1024}, false, func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader("x")))
+
+	assert.Empty(t, logs.FilterMessage("Request bodies captured").All())
+}
+
+func TestRedactor_Body_FormAndTruncatedJSON(t *testing.T) {
+	r, err := newRedactor(RedactionConfig{})
+	require.NoError(t, err)
+
+	form := r.body(bodyValue{contentType: "application/x-www-form-urlencoded", data: []byte("user=alice&password=hunter2")})
+	assert.Equal(t, "user=alice&password=[REDACTED]", form)
+
+	truncated := r.body(bodyValue{contentType: "application/json; charset=utf-8", data: []byte(`{"id":1,"token":"abcd`)})
+	assert.Equal(t, `{"id":1,"token":"[REDACTED]"`, truncated)
+}
@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithFields returns a copy of ctx carrying fields. Every log call made with the returned
+// context, or a context derived from it, includes them. A field replaces an earlier one
+// with the same key.
+func WithFields(ctx context.Context, fields ...Field) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, logFields, mergeFields(FieldsFromContext(ctx), fields))
+}
+
+// WithLogger returns a copy of ctx carrying l. The package-level logging functions
+// called with the returned context, or a context derived from it, log through l.
+func WithLogger(ctx context.Context, l Logger) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, loggerKey, l)
+}
+
+// FromContext returns the logger attached to ctx with WithLogger, or the global logger.
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(loggerKey).(Logger); ok {
+			return l
+		}
+	}
+	return GetGlobalLogger()
+}
+
+// FieldsFromContext returns the fields attached to ctx with WithFields.
+func FieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	if fields, ok := ctx.Value(logFields).([]Field); ok {
+		return fields
+	}
+	return nil
+}
+
+// FieldFromContext returns the value of the field attached to ctx under key.
+func FieldFromContext(ctx context.Context, key string) (interface{}, bool) {
+	for _, field := range FieldsFromContext(ctx) {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+// RequestIDFromContext returns the request ID attached by LoggingMiddleware.
+func RequestIDFromContext(ctx context.Context) string {
+	return stringFieldFromContext(ctx, FieldRequestID)
+}
+
+// spanFields returns the trace_id and span_id of the span active in ctx, if any.
+func spanFields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return []Field{
+		String(FieldTraceID, spanContext.TraceID().String()),
+		String(FieldSpanID, spanContext.SpanID().String()),
+	}
+}
+
+// traceIDFromContext returns the W3C trace ID of the span active in ctx, or "".
+func traceIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		return spanContext.TraceID().String()
+	}
+	return ""
+}
+
+func stringFieldFromContext(ctx context.Context, key string) string {
+	if value, ok := FieldFromContext(ctx, key); ok {
+		if s, ok := value.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// mergeFields appends fields to existing, dropping the existing fields they replace.
+func mergeFields(existing, fields []Field) []Field {
+	merged := make([]Field, 0, len(existing)+len(fields))
+	for _, field := range existing {
+		if !hasKey(fields, field.Key) {
+			merged = append(merged, field)
+		}
+	}
+	return append(merged, fields...)
+}
+
+func hasKey(fields []Field, key string) bool {
+	for _, field := range fields {
+		if field.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Custom types for context keys to avoid using basic types.
+type contextKey string
+
+const (
+	logFields = contextKey("log-fields")
+	loggerKey = contextKey("logger")
+)
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithFields_MergesWithParentFields(t *testing.T) {
+	ctx := WithFields(context.Background(), String(FieldRequestID, "r"))
+	ctx = WithFields(ctx, String("user_id", "u1"))
+
+	fields := FieldsFromContext(ctx)
+	assert.Equal(t, []Field{String(FieldRequestID, "r"), String("user_id", "u1")}, fields)
+}
+
+func TestWithFields_ReplacesFieldWithSameKey(t *testing.T) {
+	parent := WithFields(context.Background(), String("user_id", "u1"), String(FieldRoute, "/a"))
+	child := WithFields(parent, String("user_id", "u2"))
+
+	assert.Equal(t, []Field{String(FieldRoute, "/a"), String("user_id", "u2")}, FieldsFromContext(child))
+	assert.Equal(t, []Field{String("user_id", "u1"), String(FieldRoute, "/a")}, FieldsFromContext(parent))
+}
+
+func TestFieldsFromContext_EmptyContext(t *testing.T) {
+	assert.Empty(t, FieldsFromContext(context.Background()))
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+}
+
+func TestFieldFromContext_Missing(t *testing.T) {
+	ctx := WithFields(context.Background(), String(FieldRequestID, "r"))
+	_, ok := FieldFromContext(ctx, "missing")
+	assert.False(t, ok)
+}
+
+func TestZapLogger_WithBindsFields(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := newZapLogger(zap.New(core), newLevelManager(zapcore.DebugLevel))
+	ctx := WithFields(context.Background(), String(FieldRequestID, "r"), String("user_id", "ctx"))
+
+	child := l.With(String("user_id", "bound"), String("step", "a"))
+	child.Info(ctx, "child", String("step", "b"))
+	l.Info(ctx, "parent")
+
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "r", fields[FieldRequestID])
+	assert.Equal(t, "bound", fields["user_id"])
+	assert.Equal(t, "b", fields["step"])
+	assert.Equal(t, "ctx", logs.All()[1].ContextMap()["user_id"])
+	assert.NotContains(t, logs.All()[1].ContextMap(), "step")
+}
+
+func TestZapLogger_NamedJoinsComponents(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := newZapLogger(zap.New(core), newLevelManager(zapcore.DebugLevel))
+
+	l.Named("user").Named("repo").Info(context.Background(), "nested")
+	l.Named("user").With(String("step", "a")).Named("").Info(context.Background(), "empty")
+
+	assert.Equal(t, "user.repo", logs.All()[0].ContextMap()[FieldComponent])
+	assert.Equal(t, "user", logs.All()[1].ContextMap()[FieldComponent])
+	assert.Equal(t, "a", logs.All()[1].ContextMap()["step"])
+}
+
+func TestChildLogger_SharesLevels(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := newZapLogger(zap.New(core), newLevelManager(zapcore.DebugLevel))
+	child := l.Named("user")
+
+	assert.NoError(t, l.SetLevel(WarnKey))
+	child.Info(context.Background(), "dropped")
+
+	assert.Zero(t, logs.Len())
+}
+
+func TestFromContext_ReturnsCarriedLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := newZapLogger(zap.New(core), newLevelManager(zapcore.DebugLevel)).Named("limit")
+	ctx := WithLogger(context.Background(), l)
+
+	Info(WithFields(ctx, String(FieldRequestID, "r")), "via global helper")
+
+	assert.Same(t, l, FromContext(ctx))
+	assert.Same(t, GetGlobalLogger(), FromContext(context.Background()))
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "limit", fields[FieldComponent])
+	assert.Equal(t, "r", fields[FieldRequestID])
+}
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"context"
+	"errors"
 	"sync"
 )
 
@@ -22,46 +23,114 @@ func GetGlobalLogger() Logger {
 	return globalLoggerFn()
 }
 
-// Info Global logging functions for convenience.
+// GetLevelController returns the global logger's level controls, if it supports them.
+func GetLevelController() (LevelController, bool) {
+	controller, ok := GetGlobalLogger().(LevelController)
+	return controller, ok
+}
+
+// SetLevel changes the level of the global logger, e.g. when the configuration is reloaded.
+func SetLevel(level string) error {
+	controller, ok := GetLevelController()
+	if !ok {
+		return ErrLevelNotSupported
+	}
+	return controller.SetLevel(level)
+}
+
+// GetLevel returns the current level of the global logger.
+func GetLevel() string {
+	if controller, ok := GetLevelController(); ok {
+		return controller.Level()
+	}
+	return ""
+}
+
+// SetSampling changes the sampling of the global logger, e.g. when the configuration is reloaded.
+func SetSampling(cfg SamplingConfig) error {
+	controller, ok := GetGlobalLogger().(SamplingController)
+	if !ok {
+		return ErrSamplingNotSupported
+	}
+	controller.SetSampling(cfg)
+	return nil
+}
+
+// SetRedaction changes the redaction rules of the global logger, e.g. when the configuration is reloaded.
+func SetRedaction(cfg RedactionConfig) error {
+	controller, ok := GetGlobalLogger().(RedactionController)
+	if !ok {
+		return ErrRedactionNotSupported
+	}
+	return controller.SetRedaction(cfg)
+}
+
+// SetSinks replaces the sinks of the global logger.
+func SetSinks(ctx context.Context, sinks []SinkConfig) error {
+	controller, ok := GetGlobalLogger().(SinkController)
+	if !ok {
+		return ErrSinksNotSupported
+	}
+	return controller.SetSinks(ctx, sinks)
+}
+
+// Shutdown flushes and closes the sinks of the global logger.
+func Shutdown(ctx context.Context) error {
+	controller, ok := GetGlobalLogger().(SinkController)
+	if !ok {
+		return ErrSinksNotSupported
+	}
+	return controller.Shutdown(ctx)
+}
+
+// Info Global logging functions for convenience. They log through the logger carried
+// by ctx, if any, and the global logger otherwise.
 func Info(ctx context.Context, message string, fields ...Field) {
-	GetGlobalLogger().Info(ctx, message, fields...)
+	FromContext(ctx).Info(ctx, message, fields...)
 }
 
 func Warn(ctx context.Context, message string, fields ...Field) {
-	GetGlobalLogger().Warn(ctx, message, fields...)
+	FromContext(ctx).Warn(ctx, message, fields...)
 }
 
 func Error(ctx context.Context, message string, fields ...Field) {
-	GetGlobalLogger().Error(ctx, message, fields...)
+	FromContext(ctx).Error(ctx, message, fields...)
 }
 
 func Debug(ctx context.Context, message string, fields ...Field) {
-	GetGlobalLogger().Debug(ctx, message, fields...)
+	FromContext(ctx).Debug(ctx, message, fields...)
 }
 
 func Fatal(ctx context.Context, message string, fields ...Field) {
-	GetGlobalLogger().Fatal(ctx, message, fields...)
+	FromContext(ctx).Fatal(ctx, message, fields...)
 }
 
 // Infof Global logging functions for convenience.
 func Infof(ctx context.Context, format string, args ...interface{}) {
-	GetGlobalLogger().Infof(ctx, format, args...)
+	FromContext(ctx).Infof(ctx, format, args...)
 }
 
 func Warnf(ctx context.Context, format string, args ...interface{}) {
-	GetGlobalLogger().Warnf(ctx, format, args...)
+	FromContext(ctx).Warnf(ctx, format, args...)
 }
 
 func Errorf(ctx context.Context, format string, args ...interface{}) {
-	GetGlobalLogger().Errorf(ctx, format, args...)
+	FromContext(ctx).Errorf(ctx, format, args...)
 }
 
 func Debugf(ctx context.Context, format string, args ...interface{}) {
-	GetGlobalLogger().Debugf(ctx, format, args...)
+	FromContext(ctx).Debugf(ctx, format, args...)
 }
 
 func Fatalf(ctx context.Context, format string, args ...interface{}) {
-	GetGlobalLogger().Fatalf(ctx, format, args...)
+	FromContext(ctx).Fatalf(ctx, format, args...)
 }
 
 const serviceName = "go-service-template"
+
+var (
+	ErrLevelNotSupported     = errors.New("logger does not support changing its level")
+	ErrSamplingNotSupported  = errors.New("logger does not support sampling")
+	ErrRedactionNotSupported = errors.New("logger does not support redaction")
+	ErrSinksNotSupported     = errors.New("logger does not support sinks")
+)
@@ -35,6 +35,12 @@ type Logger interface {
 
 	// Fatalf logs a fatal level message with formatting and exits the program
 	Fatalf(ctx context.Context, format string, args ...interface{})
+
+	// With returns a child logger that adds fields to every entry
+	With(fields ...Field) Logger
+
+	// Named returns a child logger for a component; nested names are joined with dots
+	Named(component string) Logger
 }
 
 // The Field represents a key-value pair for structured logging.
@@ -72,11 +78,18 @@ func ErrorField(key string, err error) Field {
 	return Field{Key: key, Value: err}
 }
 
+// Body is a captured request or response payload. JSON and form bodies are redacted
+// key by key, on top of the value detectors applied to every string.
+func Body(key, contentType string, body []byte) Field {
+	return Field{Key: key, Value: bodyValue{contentType: contentType, data: body}}
+}
+
 // Common field keys for structured logging.
 const (
 	FieldService      = "service"
 	FieldRequestID    = "request-id"
-	FieldTraceID      = "trace-id"
+	FieldTraceID      = "trace_id"
+	FieldSpanID       = "span_id"
 	FieldMessage      = "message"
 	FieldSeverity     = "severity"
 	FieldError        = "error"
@@ -91,4 +104,10 @@ const (
 	FieldCaller       = "caller"
 	FieldStackTrace   = "stacktrace"
 	FieldResponseSize = "response_size"
+	FieldRoute        = "route"
+	FieldSuppressed   = "suppressed"
+	FieldComponent    = "component"
+	FieldTenant       = "tenant"
+	FieldSubject      = "subject"
+	FieldAuthMethod   = "auth_method"
 )
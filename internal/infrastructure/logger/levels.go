@@ -0,0 +1,201 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelController is implemented by loggers whose minimum level can change at runtime.
+type LevelController interface {
+	// SetLevel changes the configured level, e.g. when the configuration is reloaded.
+	SetLevel(level string) error
+	// Level returns the effective global level.
+	Level() string
+	// Override temporarily changes the level globally (route == "") or for one route
+	// template. The override reverts on its own once ttl has elapsed.
+	Override(route, level string, ttl time.Duration) (LevelOverride, error)
+	// ClearOverride removes the override for route ("" for the global override).
+	ClearOverride(route string)
+	// Levels describes the configured level and every active override.
+	Levels() LevelState
+}
+
+// LevelOverride is a temporary level change that reverts at ExpiresAt.
+type LevelOverride struct {
+	Route     string    `json:"route,omitempty"`
+	Level     string    `json:"level"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LevelState is the snapshot returned by LevelController.Levels.
+type LevelState struct {
+	Level      string          `json:"level"`
+	Configured string          `json:"configured_level"`
+	Overrides  []LevelOverride `json:"overrides"`
+}
+
+// levelManager decides which entries are written. The zap core is built with floor,
+// the lowest level any override may need, and entries are then filtered per call using
+// the route carried in the context. Writers serialize on mu and publish the route levels
+// as an immutable snapshot, so log calls never take the lock.
+type levelManager struct {
+	mu          sync.Mutex
+	configured  zapcore.Level
+	global      *levelOverride
+	routes      map[string]*levelOverride
+	routeLevels atomic.Pointer[map[string]zapcore.Level]
+	effective   zap.AtomicLevel
+	floor       zap.AtomicLevel
+}
+
+type levelOverride struct {
+	level     zapcore.Level
+	expiresAt time.Time
+	timer     *time.Timer
+}
+
+func newLevelManager(configured zapcore.Level) *levelManager {
+	m := &levelManager{
+		configured: configured,
+		routes:     map[string]*levelOverride{},
+		effective:  zap.NewAtomicLevelAt(configured),
+		floor:      zap.NewAtomicLevelAt(configured),
+	}
+	m.routeLevels.Store(&map[string]zapcore.Level{})
+	return m
+}
+
+// Enabled reports whether an entry at level should be written for ctx.
+func (m *levelManager) Enabled(ctx context.Context, level zapcore.Level) bool {
+	if !m.floor.Enabled(level) {
+		return false
+	}
+	if route := routeFromContext(ctx); route != "" {
+		if override, ok := (*m.routeLevels.Load())[route]; ok {
+			return override.Enabled(level)
+		}
+	}
+	return m.effective.Enabled(level)
+}
+
+func (m *levelManager) SetLevel(level string) error {
+	parsed, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.configured = parsed
+	m.recompute()
+	return nil
+}
+
+func (m *levelManager) Level() string {
+	return m.effective.Level().String()
+}
+
+func (m *levelManager) Override(route, level string, ttl time.Duration) (LevelOverride, error) {
+	parsed, err := ParseLevel(level)
+	if err != nil {
+		return LevelOverride{}, err
+	}
+	if ttl <= 0 {
+		ttl = DefaultOverrideTTL
+	}
+	if ttl > MaxOverrideTTL {
+		return LevelOverride{}, fmt.Errorf("%w: %s exceeds %s", ErrOverrideTooLong, ttl, MaxOverrideTTL)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clear(route)
+	override := &levelOverride{level: parsed, expiresAt: time.Now().Add(ttl)}
+	override.timer = time.AfterFunc(ttl, func() { m.expire(route, override) })
+	if route == "" {
+		m.global = override
+	} else {
+		m.routes[route] = override
+	}
+	m.recompute()
+	return LevelOverride{Route: route, Level: parsed.String(), ExpiresAt: override.expiresAt}, nil
+}
+
+func (m *levelManager) ClearOverride(route string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clear(route)
+	m.recompute()
+}
+
+func (m *levelManager) Levels() LevelState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state := LevelState{
+		Level:      m.effective.Level().String(),
+		Configured: m.configured.String(),
+		Overrides:  []LevelOverride{},
+	}
+	if m.global != nil {
+		state.Overrides = append(state.Overrides, LevelOverride{Level: m.global.level.String(), ExpiresAt: m.global.expiresAt})
+	}
+	for route, override := range m.routes {
+		state.Overrides = append(state.Overrides, LevelOverride{Route: route, Level: override.level.String(), ExpiresAt: override.expiresAt})
+	}
+	sort.Slice(state.Overrides, func(i, j int) bool { return state.Overrides[i].Route < state.Overrides[j].Route })
+	return state
+}
+
+// expire removes override unless it has already been replaced by a newer one.
+func (m *levelManager) expire(route string, override *levelOverride) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if (route == "" && m.global == override) || (route != "" && m.routes[route] == override) {
+		m.clear(route)
+		m.recompute()
+	}
+}
+
+func (m *levelManager) clear(route string) {
+	if route == "" {
+		if m.global != nil {
+			m.global.timer.Stop()
+			m.global = nil
+		}
+		return
+	}
+	if override, ok := m.routes[route]; ok {
+		override.timer.Stop()
+		delete(m.routes, route)
+	}
+}
+
+// recompute publishes the levels after a change; it must be called with mu held.
+func (m *levelManager) recompute() {
+	effective := m.configured
+	if m.global != nil {
+		effective = m.global.level
+	}
+	floor := effective
+	routeLevels := make(map[string]zapcore.Level, len(m.routes))
+	for route, override := range m.routes {
+		routeLevels[route] = override.level
+		if override.level < floor {
+			floor = override.level
+		}
+	}
+	m.routeLevels.Store(&routeLevels)
+	m.effective.SetLevel(effective)
+	m.floor.SetLevel(floor)
+}
+
+const (
+	DefaultOverrideTTL = 10 * time.Minute
+	MaxOverrideTTL     = time.Hour
+)
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLevelManager_RouteOverride_OnlyAffectsRoute(t *testing.T) {
+	m := newLevelManager(zap.InfoLevel)
+	routeCtx := WithFields(context.Background(), String(FieldRoute, "/api/v1/limit/check"))
+	otherCtx := WithFields(context.Background(), String(FieldRoute, "/health"))
+
+	_, err := m.Override("/api/v1/limit/check", DebugKey, time.Minute)
+	require.NoError(t, err)
+
+	assert.True(t, m.Enabled(routeCtx, zap.DebugLevel))
+	assert.False(t, m.Enabled(otherCtx, zap.DebugLevel))
+	assert.False(t, m.Enabled(context.Background(), zap.DebugLevel))
+	assert.Equal(t, InfoKey, m.Level())
+}
+
+func TestLevelManager_RouteOverride_CanRaiseLevel(t *testing.T) {
+	m := newLevelManager(zap.InfoLevel)
+	ctx := WithFields(context.Background(), String(FieldRoute, "/health"))
+
+	_, err := m.Override("/health", ErrorKey, time.Minute)
+	require.NoError(t, err)
+
+	assert.False(t, m.Enabled(ctx, zap.InfoLevel))
+	assert.True(t, m.Enabled(context.Background(), zap.InfoLevel))
+}
+
+func TestLevelManager_GlobalOverride_RevertsAfterTTL(t *testing.T) {
+	m := newLevelManager(zap.InfoLevel)
+
+	_, err := m.Override("", DebugKey, 20*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, DebugKey, m.Level())
+
+	assert.Eventually(t, func() bool { return m.Level() == InfoKey }, time.Second, 5*time.Millisecond)
+	assert.Empty(t, m.Levels().Overrides)
+}
+
+func TestLevelManager_SetLevel_KeepsActiveOverride(t *testing.T) {
+	m := newLevelManager(zap.InfoLevel)
+	_, err := m.Override("", DebugKey, time.Minute)
+	require.NoError(t, err)
+
+	require.NoError(t, m.SetLevel(WarnKey))
+
+	state := m.Levels()
+	assert.Equal(t, DebugKey, state.Level)
+	assert.Equal(t, WarnKey, state.Configured)
+	m.ClearOverride("")
+	assert.Equal(t, WarnKey, m.Level())
+}
+
+func TestLevelManager_Override_Validation(t *testing.T) {
+	m := newLevelManager(zap.InfoLevel)
+
+	_, err := m.Override("", "loud", time.Minute)
+	assert.ErrorIs(t, err, ErrUnknownLevel)
+
+	_, err = m.Override("", DebugKey, 2*MaxOverrideTTL)
+	assert.ErrorIs(t, err, ErrOverrideTooLong)
+}
+
+func TestLevelManager_Override_DefaultTTL(t *testing.T) {
+	m := newLevelManager(zap.InfoLevel)
+
+	override, err := m.Override("/health", DebugKey, 0)
+
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(DefaultOverrideTTL), override.ExpiresAt, time.Second)
+	m.ClearOverride("/health")
+	assert.Empty(t, m.Levels().Overrides)
+}
+
+func TestLevelManager_RouteOverride_ExpiresForLogCalls(t *testing.T) {
+	m := newLevelManager(zap.InfoLevel)
+	ctx := WithFields(context.Background(), String(FieldRoute, "/health"))
+
+	_, err := m.Override("/health", DebugKey, 20*time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, m.Enabled(ctx, zap.DebugLevel))
+
+	assert.Eventually(t, func() bool { return !m.Enabled(ctx, zap.DebugLevel) }, time.Second, 5*time.Millisecond)
+}
+
+func TestLevelManager_Enabled_ConcurrentWithOverrides(t *testing.T) {
+	m := newLevelManager(zap.InfoLevel)
+	ctx := WithFields(context.Background(), String(FieldRoute, "/health"))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 100 {
+			_, _ = m.Override("/health", DebugKey, time.Minute)
+			m.ClearOverride("/health")
+		}
+	}()
+
+	for range 1000 {
+		m.Enabled(ctx, zap.DebugLevel)
+	}
+	<-done
+	assert.False(t, m.Enabled(ctx, zap.DebugLevel))
+}
@@ -2,9 +2,13 @@ package logger
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -12,133 +16,266 @@ import (
 
 // zapLogger implements the Logger interface using zap.
 type zapLogger struct {
-	logger *zap.Logger
-	fields []Field
+	serviceName string
+	sinks       *atomic.Pointer[sinkSet]
+	levels      *levelManager
+	sampler     *sampler
+	redactor    *atomic.Pointer[redactor]
+	name        string
+	fields      []Field
 }
 
-// NewLogger creates a new logger instance.
+var (
+	_ LevelController     = (*zapLogger)(nil)
+	_ SamplingController  = (*zapLogger)(nil)
+	_ RedactionController = (*zapLogger)(nil)
+	_ SinkController      = (*zapLogger)(nil)
+)
+
+// NewLogger creates a new logger instance writing JSON to stdout. Use SetSinks to
+// write elsewhere.
 func NewLogger(serviceName string) Logger {
-	config := zap.NewProductionConfig()
+	// Set log level based on environment
+	level, err := ParseLevel(os.Getenv(LogLevelKey))
+	if err != nil {
+		level = zap.InfoLevel
+	}
+	levels := newLevelManager(level)
+
+	sinks, err := buildSinks(context.Background(), serviceName, levels.floor, DefaultSinks())
+	if err != nil {
+		panic("Failed to initialize logger: " + err.Error())
+	}
 
-	// Configure JSON output with required fields
-	config.EncoderConfig.TimeKey = FieldTimeStamp
-	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-	config.EncoderConfig.LevelKey = FieldSeverity
-	config.EncoderConfig.MessageKey = FieldMessage
-	config.EncoderConfig.CallerKey = FieldCaller
-	config.EncoderConfig.StacktraceKey = FieldStackTrace
+	l := newZapLogger(sinks.logger, levels)
+	l.serviceName = serviceName
+	l.sinks.Store(sinks)
+	return l
+}
 
-	// Set log level based on environment
-	env := strings.ToLower(os.Getenv(LogLevelKey))
-	switch env {
+// newZapLogger wraps logger with sampling disabled and the built-in redaction rules.
+func newZapLogger(logger *zap.Logger, levels *levelManager) *zapLogger {
+	defaultRedactor, err := newRedactor(RedactionConfig{})
+	if err != nil {
+		panic("Failed to initialize log redaction: " + err.Error())
+	}
+	l := &zapLogger{
+		sinks:    &atomic.Pointer[sinkSet]{},
+		levels:   levels,
+		sampler:  newSampler(),
+		redactor: &atomic.Pointer[redactor]{},
+		fields:   []Field{},
+	}
+	l.sinks.Store(&sinkSet{logger: logger})
+	l.redactor.Store(defaultRedactor)
+	return l
+}
+
+// SetSinks replaces the sinks entries are written to, then flushes and closes the previous ones.
+func (l *zapLogger) SetSinks(ctx context.Context, sinks []SinkConfig) error {
+	set, err := buildSinks(ctx, l.serviceName, l.levels.floor, sinks)
+	if err != nil {
+		return err
+	}
+	return l.sinks.Swap(set).close(ctx)
+}
+
+// Shutdown flushes and closes the sinks. Entries logged afterwards go to stdout.
+func (l *zapLogger) Shutdown(ctx context.Context) error {
+	set, err := buildSinks(ctx, l.serviceName, l.levels.floor, DefaultSinks())
+	if err != nil {
+		return err
+	}
+	return l.sinks.Swap(set).close(ctx)
+}
+
+// ParseLevel maps a LOG_LEVEL value to a zap level.
+func ParseLevel(level string) (zapcore.Level, error) {
+	switch strings.ToLower(level) {
 	case DebugKey:
-		config.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+		return zap.DebugLevel, nil
 	case InfoKey:
-		config.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+		return zap.InfoLevel, nil
 	case WarnKey:
-		config.Level = zap.NewAtomicLevelAt(zap.WarnLevel)
+		return zap.WarnLevel, nil
 	case ErrorKey:
-		config.Level = zap.NewAtomicLevelAt(zap.ErrorLevel)
+		return zap.ErrorLevel, nil
 	default:
-		config.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+		return zap.InfoLevel, fmt.Errorf("%w: %q", ErrUnknownLevel, level)
 	}
+}
 
-	// Build logger with service name
-	logger, err := config.Build(
-		zap.AddCallerSkip(1), // Skip this function in caller stack
-		zap.Fields(zap.String(FieldService, serviceName)),
-	)
-	if err != nil {
-		panic("Failed to initialize logger: " + err.Error())
-	}
+// SetLevel changes the configured level of the logger at runtime.
+func (l *zapLogger) SetLevel(level string) error {
+	return l.levels.SetLevel(level)
+}
+
+// Level returns the effective global level of the logger.
+func (l *zapLogger) Level() string {
+	return l.levels.Level()
+}
+
+// Override temporarily changes the level globally or for a single route.
+func (l *zapLogger) Override(route, level string, ttl time.Duration) (LevelOverride, error) {
+	return l.levels.Override(route, level, ttl)
+}
 
-	return &zapLogger{
-		logger: logger,
-		fields: []Field{},
+// ClearOverride removes a temporary level override.
+func (l *zapLogger) ClearOverride(route string) {
+	l.levels.ClearOverride(route)
+}
+
+// Levels describes the configured level and the active overrides.
+func (l *zapLogger) Levels() LevelState {
+	return l.levels.Levels()
+}
+
+// SetSampling changes how entries are sampled and deduplicated at runtime.
+func (l *zapLogger) SetSampling(cfg SamplingConfig) {
+	l.sampler.set(cfg)
+}
+
+// SetRedaction changes which keys are redacted and how at runtime.
+func (l *zapLogger) SetRedaction(cfg RedactionConfig) error {
+	r, err := newRedactor(cfg)
+	if err != nil {
+		return err
 	}
+	l.redactor.Store(r)
+	return nil
 }
 
 // Info logs an info level message.
 func (l *zapLogger) Info(ctx context.Context, message string, fields ...Field) {
-	zapFields := l.buildZapFields(ctx, fields...)
-	l.logger.Info(message, zapFields...)
+	l.log(ctx, zap.InfoLevel, message, fields...)
 }
 
 // Infof logs an info level message with formatting.
 func (l *zapLogger) Infof(ctx context.Context, format string, args ...interface{}) {
-	zapFields := l.buildZapFields(ctx)
-	message := fmt.Sprintf(format, args...)
-	l.logger.Info(message, zapFields...)
+	l.logf(ctx, zap.InfoLevel, format, args...)
 }
 
 // Warn logs a warning level message.
 func (l *zapLogger) Warn(ctx context.Context, message string, fields ...Field) {
-	zapFields := l.buildZapFields(ctx, fields...)
-	l.logger.Warn(message, zapFields...)
+	l.log(ctx, zap.WarnLevel, message, fields...)
 }
 
 // Warnf logs a warning level message with formatting.
 func (l *zapLogger) Warnf(ctx context.Context, format string, args ...interface{}) {
-	zapFields := l.buildZapFields(ctx)
-	message := fmt.Sprintf(format, args...)
-	l.logger.Warn(message, zapFields...)
+	l.logf(ctx, zap.WarnLevel, format, args...)
 }
 
 // Error logs an error level message.
 func (l *zapLogger) Error(ctx context.Context, message string, fields ...Field) {
-	zapFields := l.buildZapFields(ctx, fields...)
-	l.logger.Error(message, zapFields...)
+	l.log(ctx, zap.ErrorLevel, message, fields...)
 }
 
 // Errorf logs an error level message with formatting.
 func (l *zapLogger) Errorf(ctx context.Context, format string, args ...interface{}) {
-	zapFields := l.buildZapFields(ctx)
-	message := fmt.Sprintf(format, args...)
-	l.logger.Error(message, zapFields...)
+	l.logf(ctx, zap.ErrorLevel, format, args...)
 }
 
 // Debug logs a debug level message.
 func (l *zapLogger) Debug(ctx context.Context, message string, fields ...Field) {
-	zapFields := l.buildZapFields(ctx, fields...)
-	l.logger.Debug(message, zapFields...)
+	l.log(ctx, zap.DebugLevel, message, fields...)
 }
 
 // Debugf logs a debug level message with formatting.
 func (l *zapLogger) Debugf(ctx context.Context, format string, args ...interface{}) {
-	zapFields := l.buildZapFields(ctx)
-	message := fmt.Sprintf(format, args...)
-	l.logger.Debug(message, zapFields...)
+	l.logf(ctx, zap.DebugLevel, format, args...)
 }
 
 // Fatal logs a fatal level message and exits the program.
 func (l *zapLogger) Fatal(ctx context.Context, message string, fields ...Field) {
-	zapFields := l.buildZapFields(ctx, fields...)
-	l.logger.Fatal(message, zapFields...)
+	l.log(ctx, zap.FatalLevel, message, fields...)
 }
 
 // Fatalf logs a fatal level message with formatting and exits the program.
 func (l *zapLogger) Fatalf(ctx context.Context, format string, args ...interface{}) {
-	zapFields := l.buildZapFields(ctx)
-	message := fmt.Sprintf(format, args...)
-	l.logger.Fatal(message, zapFields...)
+	l.logf(ctx, zap.FatalLevel, format, args...)
 }
 
-// buildZapFields converts Field slice to zap.Field slice and adds context fields.
-func (l *zapLogger) buildZapFields(ctx context.Context, fields ...Field) []zap.Field {
-	zapFields := make([]zap.Field, 0, len(l.fields)+len(fields)+TwoForRequestIDAndTraceID) // +2 for request-id and trace-id
+// With returns a child logger sharing the sinks, levels, sampling and redaction of l
+// and adding fields to every entry. Fields passed to a log call take precedence over
+// bound fields with the same key, which take precedence over context fields.
+func (l *zapLogger) With(fields ...Field) Logger {
+	child := *l
+	child.fields = mergeFields(l.fields, fields)
+	return &child
+}
 
-	// Add existing fields.
-	for _, field := range l.fields {
-		zapFields = append(zapFields, l.convertField(field))
+// Named returns a child logger whose entries carry the component name, appended to
+// the name of l with a dot.
+func (l *zapLogger) Named(component string) Logger {
+	if component == "" {
+		return l
+	}
+	child := *l
+	child.name = component
+	if l.name != "" {
+		child.name = l.name + "." + component
+	}
+	child.fields = mergeFields(l.fields, []Field{String(FieldComponent, child.name)})
+	return &child
+}
+
+// log writes the entry if the level is enabled for the route carried by ctx and the
+// entry survives sampling and deduplication.
+func (l *zapLogger) log(ctx context.Context, level zapcore.Level, message string, fields ...Field) {
+	if !l.enabled(ctx, level, message) {
+		return
 	}
+	l.write(ctx, level, message, fields)
+}
 
-	// Add context fields (request-id and trace-id).
-	if requestID := l.getRequestID(ctx); requestID != "" {
-		zapFields = append(zapFields, zap.String(FieldRequestID, requestID))
+// logf formats the message only when the entry will be written. Sampling groups
+// entries by format rather than by formatted message.
+func (l *zapLogger) logf(ctx context.Context, level zapcore.Level, format string, args ...interface{}) {
+	if !l.enabled(ctx, level, format) {
+		return
 	}
+	l.write(ctx, level, fmt.Sprintf(format, args...), nil)
+}
 
-	if traceID := l.getTraceID(ctx); traceID != "" {
-		zapFields = append(zapFields, zap.String(FieldTraceID, traceID))
+func (l *zapLogger) enabled(ctx context.Context, level zapcore.Level, message string) bool {
+	if level < zap.DPanicLevel && !l.levels.Enabled(ctx, level) {
+		return false
+	}
+	return l.sampler.sample(ctx, level, message)
+}
+
+func (l *zapLogger) write(ctx context.Context, level zapcore.Level, message string, fields []Field) {
+	suppressed, ok := l.sampler.dedupe(level, message, fields)
+	if !ok {
+		return
+	}
+	zapFields := l.buildZapFields(ctx, fields...)
+	if suppressed > 0 {
+		zapFields = append(zapFields, zap.Int(FieldSuppressed, suppressed))
+	}
+	l.sinks.Load().logger.Log(level, l.redactor.Load().scrub("", message), zapFields...)
+}
+
+// buildZapFields converts Field slice to zap.Field slice and adds the fields bound with
+// With and Named and the fields carried by ctx, including the trace and span IDs of the
+// active span. Fields passed to the call take precedence over bound fields, which take
+// precedence over context fields with the same key.
+func (l *zapLogger) buildZapFields(ctx context.Context, fields ...Field) []zap.Field {
+	contextFields := append(spanFields(ctx), FieldsFromContext(ctx)...)
+	zapFields := make([]zap.Field, 0, len(l.fields)+len(contextFields)+len(fields))
+
+	// Add context fields (trace_id, span_id, request-id, route and anything attached with WithFields).
+	for _, field := range contextFields {
+		if !hasKey(fields, field.Key) && !hasKey(l.fields, field.Key) {
+			zapFields = append(zapFields, l.convertField(field))
+		}
+	}
+
+	// Add bound fields.
+	for _, field := range l.fields {
+		if !hasKey(fields, field.Key) {
+			zapFields = append(zapFields, l.convertField(field))
+		}
 	}
 
 	// Add new fields.
@@ -149,11 +286,16 @@ func (l *zapLogger) buildZapFields(ctx context.Context, fields ...Field) []zap.F
 	return zapFields
 }
 
-// convertField converts a Field to a zap.Field.
+// convertField converts a Field to a zap.Field, redacting denied keys and the sensitive
+// values detected in strings and error messages.
 func (l *zapLogger) convertField(field Field) zap.Field {
+	r := l.redactor.Load()
+	if r.denied(field.Key) {
+		return zap.String(field.Key, r.value(field.Key, field.Value))
+	}
 	switch v := field.Value.(type) {
 	case string:
-		return zap.String(field.Key, v)
+		return zap.String(field.Key, r.scrub(field.Key, v))
 	case int:
 		return zap.Int(field.Key, v)
 	case int64:
@@ -162,62 +304,37 @@ func (l *zapLogger) convertField(field Field) zap.Field {
 		return zap.Float64(field.Key, v)
 	case bool:
 		return zap.Bool(field.Key, v)
-	case error:
-		return zap.Error(v)
 	default:
-		return zap.Any(field.Key, v)
+		return redactedField(r, field)
 	}
 }
 
-// getRequestID extracts request ID from context.
-func (l *zapLogger) getRequestID(ctx context.Context) string {
-	if ctx == nil {
-		return ""
-	}
-
-	// Try to get request ID from context.
-	if requestID, ok := ctx.Value("request-id").(string); ok {
-		return requestID
-	}
-
-	// Try common request ID header names
-	if requestID, ok := ctx.Value("X-Request-ID").(string); ok {
-		return requestID
-	}
-
-	if requestID, ok := ctx.Value("Request-ID").(string); ok {
-		return requestID
-	}
-
-	return ""
-}
-
-// getTraceID extracts trace ID from context.
-func (l *zapLogger) getTraceID(ctx context.Context) string {
-	if ctx == nil {
-		return ""
-	}
-
-	// Try to get trace ID from context.
-	if traceID, ok := ctx.Value("trace-id").(string); ok {
-		return traceID
-	}
-
-	// Try common trace ID header names.
-	if traceID, ok := ctx.Value("X-Trace-ID").(string); ok {
-		return traceID
-	}
-
-	if traceID, ok := ctx.Value("Trace-ID").(string); ok {
-		return traceID
+// redactedField converts the values that need structure-aware redaction.
+func redactedField(r *redactor, field Field) zap.Field {
+	switch v := field.Value.(type) {
+	case bodyValue:
+		return zap.String(field.Key, r.body(v))
+	case map[string]string:
+		return zap.Object(field.Key, redactedMap{redactor: r, values: v})
+	case error:
+		if text, scrubbed := v.Error(), r.scrub(FieldError, v.Error()); scrubbed != text {
+			return zap.String(field.Key, scrubbed)
+		}
+		return zap.NamedError(field.Key, v)
+	default:
+		if object, ok := r.object(v); ok {
+			if json.Valid([]byte(object)) {
+				return zap.Reflect(field.Key, json.RawMessage(object))
+			}
+			return zap.String(field.Key, object)
+		}
+		return zap.Any(field.Key, v)
 	}
-
-	return ""
 }
 
 // Sync flushes any buffered log entries.
 func (l *zapLogger) Sync() error {
-	return l.logger.Sync()
+	return l.sinks.Load().logger.Sync()
 }
 
 const (
@@ -228,4 +345,11 @@ const (
 	LogLevelKey = "LOG_LEVEL"
 )
 
-const TwoForRequestIDAndTraceID = 2
+// loggerCallerSkip skips the Logger method, log/logf and write in the caller stack.
+const loggerCallerSkip = 3
+
+var (
+	ErrUnknownLevel      = errors.New("unknown log level")
+	ErrOverrideTooLong   = errors.New("level override too long")
+	ErrUnknownRedactMode = errors.New("unknown redaction mode")
+)
@@ -9,52 +9,53 @@ import (
 )
 
 // LoggingMiddleware creates a middleware that adds structured logging to all requests.
+// It must run after otelgin.Middleware so the span it starts is already on the request
+// context; trace_id and span_id are then taken from that span.
 func LoggingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
-		requestID, traceID := fetchRequestAndTraceIDs(c)
+		requestID := fetchRequestID(c)
+		traceID := traceIDFromContext(c.Request.Context())
 		addTraceIDsInResponseHeaders(c, requestID, traceID)
-		ctx := createContextWithTraceIDs(requestID, traceID)
-		setLoggerInContext(ctx, c)
+		ctx := WithFields(c.Request.Context(), String(FieldRequestID, requestID))
+		if route := c.FullPath(); route != "" {
+			ctx = WithFields(ctx, String(FieldRoute, route))
+		}
+		SetLogContext(ctx, c)
 
-		requestStartLog(ctx, c, requestID, traceID)
+		requestStartLog(ctx, c)
 
 		c.Next()
-		requestCompleteLog(ctx, c, start, requestID, traceID)
+		requestCompleteLog(ctx, c, start)
 	}
 }
 
-func fetchRequestAndTraceIDs(c *gin.Context) (requestID, traceID string) {
-	requestID = c.GetHeader(XRequestID)
-	if requestID == "" {
-		requestID = uuid.New().String()
-	}
-	traceID = c.GetHeader(XTraceID)
-	if traceID == "" {
-		traceID = uuid.New().String()
+func fetchRequestID(c *gin.Context) string {
+	if requestID := c.GetHeader(XRequestID); requestID != "" {
+		return requestID
 	}
-	return requestID, traceID
+	return uuid.New().String()
 }
 
+// addTraceIDsInResponseHeaders echoes the request ID and, when the request is traced,
+// the W3C trace ID so clients can quote them when reporting problems.
 func addTraceIDsInResponseHeaders(c *gin.Context, requestID, traceID string) {
 	c.Header(XRequestID, requestID)
-	c.Header(XTraceID, traceID)
-}
-
-func createContextWithTraceIDs(requestID, traceID string) context.Context {
-	ctx := context.WithValue(context.Background(), RequestID, requestID)
-	ctx = context.WithValue(ctx, TraceID, traceID)
-	return ctx
+	if traceID != "" {
+		c.Header(XTraceID, traceID)
+	}
 }
 
-func setLoggerInContext(ctx context.Context, c *gin.Context) {
+// SetLogContext replaces the request context so that handlers and everything they call
+// with c.Request.Context() log with the request fields. Middlewares running after
+// LoggingMiddleware use it to add fields to the rest of the request.
+func SetLogContext(ctx context.Context, c *gin.Context) {
+	c.Request = c.Request.WithContext(ctx)
 	c.Set(LogContext, ctx)
 }
 
-func requestStartLog(ctx context.Context, c *gin.Context, requestID, traceID string) {
+func requestStartLog(ctx context.Context, c *gin.Context) {
 	GetGlobalLogger().Info(ctx, "Request started",
-		String(FieldRequestID, requestID),
-		String(FieldTraceID, traceID),
 		String(FieldMethod, c.Request.Method),
 		String(FieldPath, c.Request.URL.Path),
 		String(FieldIP, c.ClientIP()),
@@ -62,11 +63,9 @@ func requestStartLog(ctx context.Context, c *gin.Context, requestID, traceID str
 	)
 }
 
-func requestCompleteLog(ctx context.Context, c *gin.Context, start time.Time, requestID, traceID string) {
+func requestCompleteLog(ctx context.Context, c *gin.Context, start time.Time) {
 	duration := time.Since(start)
 	GetGlobalLogger().Info(ctx, "Request completed",
-		String(FieldRequestID, requestID),
-		String(FieldTraceID, traceID),
 		String(FieldMethod, c.Request.Method),
 		String(FieldPath, c.Request.URL.Path),
 		Int(FieldStatusCode, c.Writer.Status()),
@@ -75,23 +74,27 @@ func requestCompleteLog(ctx context.Context, c *gin.Context, start time.Time, re
 	)
 }
 
-// GetLogContext extracts the logging context from Gin context.
+// GetLogContext extracts the logging context from Gin context, falling back to the
+// request context when LoggingMiddleware did not run.
 func GetLogContext(c *gin.Context) context.Context {
 	if ctx, exists := c.Get(LogContext); exists {
 		if logCtx, ok := ctx.(context.Context); ok {
 			return logCtx
 		}
 	}
+	if c.Request != nil {
+		return c.Request.Context()
+	}
 	return context.Background()
 }
 
-// Custom types for context keys to avoid using basic types.
-type contextKey string
+// routeFromContext returns the route template the request matched, used for per-route levels.
+func routeFromContext(ctx context.Context) string {
+	return stringFieldFromContext(ctx, FieldRoute)
+}
 
 const (
 	XRequestID = "X-Request-ID"
 	XTraceID   = "X-Trace-ID"
-	RequestID  = contextKey("request-id")
-	TraceID    = contextKey("trace-id")
 	LogContext = "log-context"
 )
@@ -1,12 +1,17 @@
 package logger
 
 import (
+    "context"
     "net/http"
     "net/http/httptest"
     "testing"
 
     "github.com/gin-gonic/gin"
     "github.com/stretchr/testify/assert"
+    "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+    "go.opentelemetry.io/otel/propagation"
+    sdktrace "go.opentelemetry.io/otel/sdk/trace"
+    "go.opentelemetry.io/otel/trace"
 )
 
 func TestLoggingMiddleware_SetsTracingHeaders(t *testing.T) {
@@ -41,20 +46,19 @@ func TestFetchRequestID_GeneratesWhenMissing(t *testing.T) {
     c, _ := gin.CreateTestContext(w)
     c.Request = httptest.NewRequest("GET", "/", nil)
     
-    rid, _ := fetchRequestAndTraceIDs(c)
+    rid := fetchRequestID(c)
     
     assert.NotEmpty(t, rid)
 }
 
-func TestFetchTraceID_GeneratesWhenMissing(t *testing.T) {
+func TestFetchRequestID_UsesHeader(t *testing.T) {
     gin.SetMode(gin.TestMode)
     w := httptest.NewRecorder()
     c, _ := gin.CreateTestContext(w)
     c.Request = httptest.NewRequest("GET", "/", nil)
-    
-    _, tid := fetchRequestAndTraceIDs(c)
-    
-    assert.NotEmpty(t, tid)
+    c.Request.Header.Set(XRequestID, "rid")
+
+    assert.Equal(t, "rid", fetchRequestID(c))
 }
 
 func TestAddTraceIDsInResponseHeaders_SetsRequestID(t *testing.T) {
@@ -77,26 +81,74 @@ func TestAddTraceIDsInResponseHeaders_SetsTraceID(t *testing.T) {
     assert.Equal(t, "t", w.Header().Get(XTraceID))
 }
 
-func TestCreateContextWithTraceIDs_SetsRequestID(t *testing.T) {
-    ctx := createContextWithTraceIDs("r", "t")
-    assert.NotNil(t, ctx.Value(RequestID))
-}
+func TestAddTraceIDsInResponseHeaders_OmitsTraceIDWithoutSpan(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    w := httptest.NewRecorder()
+    c, _ := gin.CreateTestContext(w)
+
+    addTraceIDsInResponseHeaders(c, "r", "")
 
-func TestCreateContextWithTraceIDs_SetsTraceID(t *testing.T) {
-    ctx := createContextWithTraceIDs("r", "t")
-    assert.NotNil(t, ctx.Value(TraceID))
+    assert.Empty(t, w.Header().Get(XTraceID))
 }
 
 func TestSetLoggerInContext_AndGetLogContext(t *testing.T) {
     gin.SetMode(gin.TestMode)
     w := httptest.NewRecorder()
     c, _ := gin.CreateTestContext(w)
-    ctx := createContextWithTraceIDs("r", "t")
+    c.Request = httptest.NewRequest("GET", "/", nil)
+    ctx := WithFields(context.Background(), String(FieldRequestID, "r"))
     
-    setLoggerInContext(ctx, c)
+    SetLogContext(ctx, c)
     
     got := GetLogContext(c)
-    assert.NotNil(t, got)
+    assert.Equal(t, "r", RequestIDFromContext(got))
+    assert.Equal(t, "r", RequestIDFromContext(c.Request.Context()))
+}
+
+func TestLoggingMiddleware_UsesOTelSpan(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    provider := sdktrace.NewTracerProvider()
+    r := gin.New()
+    r.Use(otelgin.Middleware("svc-test", otelgin.WithTracerProvider(provider)))
+    r.Use(LoggingMiddleware())
+
+    var handlerCtx context.Context
+    r.GET("/ping", func(c *gin.Context) {
+        handlerCtx = GetLogContext(c)
+        c.String(200, "pong")
+    })
+
+    w := httptest.NewRecorder()
+    req := httptest.NewRequest("GET", "/ping", nil)
+    r.ServeHTTP(w, req)
+
+    spanContext := trace.SpanContextFromContext(handlerCtx)
+    assert.True(t, spanContext.IsValid())
+    assert.Equal(t, spanContext.TraceID().String(), w.Header().Get(XTraceID))
+
+    fields := spanFields(handlerCtx)
+    assert.Equal(t, []Field{
+        String(FieldTraceID, spanContext.TraceID().String()),
+        String(FieldSpanID, spanContext.SpanID().String()),
+    }, fields)
+    assert.Equal(t, "/ping", routeFromContext(handlerCtx))
 }
 
+func TestLoggingMiddleware_EchoesIncomingTraceparent(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    provider := sdktrace.NewTracerProvider()
+    r := gin.New()
+    r.Use(otelgin.Middleware("svc-test",
+        otelgin.WithTracerProvider(provider),
+        otelgin.WithPropagators(propagation.TraceContext{}),
+    ))
+    r.Use(LoggingMiddleware())
+    r.GET("/ping", func(c *gin.Context) { c.String(200, "pong") })
 
+    w := httptest.NewRecorder()
+    req := httptest.NewRequest("GET", "/ping", nil)
+    req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", w.Header().Get(XTraceID))
+}
@@ -518,6 +518,59 @@ func (_c *Logger_Infof_Call) RunAndReturn(run func(ctx context.Context, format s
 	return _c
 }
 
+// Named provides a mock function for the type Logger
+func (_mock *Logger) Named(component string) logger.Logger {
+	ret := _mock.Called(component)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Named")
+	}
+
+	var r0 logger.Logger
+	if returnFunc, ok := ret.Get(0).(func(string) logger.Logger); ok {
+		r0 = returnFunc(component)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(logger.Logger)
+		}
+	}
+	return r0
+}
+
+// Logger_Named_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Named'
+type Logger_Named_Call struct {
+	*mock.Call
+}
+
+// Named is a helper method to define mock.On call
+//   - component string
+func (_e *Logger_Expecter) Named(component interface{}) *Logger_Named_Call {
+	return &Logger_Named_Call{Call: _e.mock.On("Named", component)}
+}
+
+func (_c *Logger_Named_Call) Run(run func(component string)) *Logger_Named_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Logger_Named_Call) Return(_a0 logger.Logger) *Logger_Named_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Logger_Named_Call) RunAndReturn(run func(component string) logger.Logger) *Logger_Named_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Warn provides a mock function for the type Logger
 func (_mock *Logger) Warn(ctx context.Context, message string, fields ...logger.Field) {
 	if len(fields) > 0 {
@@ -637,3 +690,64 @@ func (_c *Logger_Warnf_Call) RunAndReturn(run func(ctx context.Context, format s
 	_c.Run(run)
 	return _c
 }
+
+// With provides a mock function for the type Logger
+func (_mock *Logger) With(fields ...logger.Field) logger.Logger {
+	var ret mock.Arguments
+	if len(fields) > 0 {
+		ret = _mock.Called(fields)
+	} else {
+		ret = _mock.Called()
+	}
+
+	if len(ret) == 0 {
+		panic("no return value specified for With")
+	}
+
+	var r0 logger.Logger
+	if returnFunc, ok := ret.Get(0).(func(...logger.Field) logger.Logger); ok {
+		r0 = returnFunc(fields...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(logger.Logger)
+		}
+	}
+	return r0
+}
+
+// Logger_With_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'With'
+type Logger_With_Call struct {
+	*mock.Call
+}
+
+// With is a helper method to define mock.On call
+//   - fields ...logger.Field
+func (_e *Logger_Expecter) With(fields ...interface{}) *Logger_With_Call {
+	return &Logger_With_Call{Call: _e.mock.On("With",
+		append([]interface{}{}, fields...)...)}
+}
+
+func (_c *Logger_With_Call) Run(run func(fields ...logger.Field)) *Logger_With_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []logger.Field
+		var variadicArgs []logger.Field
+		if len(args) > 0 {
+			variadicArgs = args[0].([]logger.Field)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *Logger_With_Call) Return(_a0 logger.Logger) *Logger_With_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Logger_With_Call) RunAndReturn(run func(fields ...logger.Field) logger.Logger) *Logger_With_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,288 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// RedactionConfig controls how sensitive values are kept out of log entries.
+type RedactionConfig struct {
+	// Keys lists extra field keys whose values are always redacted, on top of the
+	// built-in credential and PII keys. Keys match case-insensitively.
+	Keys []string
+	// Mode is RedactMask (the default) or RedactHash.
+	Mode string
+}
+
+// RedactionController is implemented by loggers whose redaction can change at runtime.
+type RedactionController interface {
+	SetRedaction(cfg RedactionConfig) error
+}
+
+// redactor masks or hashes the values of denied keys and any email address, phone
+// number, card number or bearer token detected in other string values.
+type redactor struct {
+	keys map[string]struct{}
+	hash bool
+}
+
+func newRedactor(cfg RedactionConfig) (*redactor, error) {
+	r := &redactor{keys: map[string]struct{}{}}
+	switch cfg.Mode {
+	case "", RedactMask:
+	case RedactHash:
+		r.hash = true
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownRedactMode, cfg.Mode)
+	}
+	for _, key := range append(defaultRedactedKeys(), cfg.Keys...) {
+		r.keys[normalizeKey(key)] = struct{}{}
+	}
+	return r, nil
+}
+
+func (r *redactor) denied(key string) bool {
+	_, ok := r.keys[normalizeKey(key)]
+	return ok
+}
+
+// value returns the text logged in place of the value of a denied key. Keys with their
+// own mask, such as email, keep the parts that help debugging in mask mode.
+func (r *redactor) value(key string, value interface{}) string {
+	text, masked := fmt.Sprint(value), redactedPlaceholder
+	if mask, ok := keyMasks[normalizeKey(key)]; ok {
+		masked = mask(text)
+	}
+	return r.conceal(text, masked)
+}
+
+// object redacts a struct or map key by key through its JSON encoding, so that a value
+// logged with Any is held to the same deny-list as flat fields. It reports false for
+// other values and for types marshaling themselves with zapcore.ObjectMarshaler.
+func (r *redactor) object(value interface{}) (string, bool) {
+	if _, ok := value.(zapcore.ObjectMarshaler); ok {
+		return "", false
+	}
+	if kind := reflect.Indirect(reflect.ValueOf(value)).Kind(); kind != reflect.Struct && kind != reflect.Map {
+		return "", false
+	}
+	data, err := json.Marshal(value)
+	if err != nil || !bytes.HasPrefix(data, []byte("{")) {
+		return "", false
+	}
+	return r.body(bodyValue{contentType: jsonContentType, data: data}), true
+}
+
+// scrub replaces the sensitive values detected in text.
+func (r *redactor) scrub(key, text string) string {
+	if _, ok := trustedKeys[key]; ok || text == "" {
+		return text
+	}
+	if strings.Contains(strings.ToLower(text), "bearer") {
+		text = bearerPattern.ReplaceAllStringFunc(text, func(match string) string {
+			return bearerPattern.ReplaceAllString(match, "$1") + r.conceal(match, redactedPlaceholder)
+		})
+	}
+	if strings.Contains(text, "@") {
+		text = emailPattern.ReplaceAllStringFunc(text, func(match string) string {
+			return r.conceal(match, maskEmail(match))
+		})
+	}
+	if countDigits(text) >= minPhoneDigits {
+		text = cardPattern.ReplaceAllStringFunc(text, func(match string) string {
+			if !luhnValid(match) {
+				return match
+			}
+			return r.conceal(match, maskDigits(match, cardDigitsKept))
+		})
+		text = phonePattern.ReplaceAllStringFunc(text, func(match string) string {
+			return r.conceal(match, maskDigits(match, phoneDigitsKept))
+		})
+	}
+	return text
+}
+
+// body redacts the denied keys of a JSON or form body, then scrubs it like any string.
+// Truncated JSON is handled too, as keys are matched textually.
+func (r *redactor) body(body bodyValue) string {
+	text := string(body.data)
+	mediaType, _, _ := strings.Cut(body.contentType, ";")
+	switch {
+	case strings.HasSuffix(mediaType, "json"):
+		text = jsonFieldPattern.ReplaceAllStringFunc(text, func(match string) string {
+			parts := jsonFieldPattern.FindStringSubmatch(match)
+			if !r.denied(parts[1]) {
+				return match
+			}
+			return `"` + parts[1] + `":"` + r.value(parts[1], strings.Trim(parts[2], `"`)) + `"`
+		})
+	case strings.TrimSpace(mediaType) == formContentType:
+		text = formFieldPattern.ReplaceAllStringFunc(text, func(match string) string {
+			parts := formFieldPattern.FindStringSubmatch(match)
+			key, err := url.QueryUnescape(parts[2])
+			if err != nil || !r.denied(key) {
+				return match
+			}
+			return parts[1] + parts[2] + "=" + r.value(key, parts[3])
+		})
+	}
+	return r.scrub("", text)
+}
+
+// conceal returns masked, or a short stable hash of value in hash mode so that
+// entries about the same value can still be correlated.
+func (r *redactor) conceal(value, masked string) string {
+	if !r.hash {
+		return masked
+	}
+	sum := sha256.Sum256([]byte(value))
+	return hashPrefix + hex.EncodeToString(sum[:])[:hashLength]
+}
+
+// maskEmail keeps the first character and the domain: "alice@example.com" -> "a***@example.com".
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return redactedPlaceholder
+	}
+	return email[:1] + maskRun + email[at:]
+}
+
+// maskDigits replaces every digit but the last keep ones, preserving separators.
+func maskDigits(value string, keep int) string {
+	remaining := countDigits(value)
+	masked := []rune(value)
+	for i, c := range masked {
+		if unicode.IsDigit(c) {
+			if remaining > keep {
+				masked[i] = '*'
+			}
+			remaining--
+		}
+	}
+	return string(masked)
+}
+
+func countDigits(value string) int {
+	count := 0
+	for _, c := range value {
+		if c >= '0' && c <= '9' {
+			count++
+		}
+	}
+	return count
+}
+
+// luhnValid reports whether the digits in value pass the Luhn checksum used by card numbers.
+func luhnValid(value string) bool {
+	sum, double := 0, false
+	for i := len(value) - 1; i >= 0; i-- {
+		c := value[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		digit := int(c - '0')
+		if double {
+			digit *= 2
+			if digit > maxDigit {
+				digit -= maxDigit
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%(maxDigit+1) == 0
+}
+
+// bodyValue is a payload logged with Body.
+type bodyValue struct {
+	contentType string
+	data        []byte
+}
+
+// redactedMap logs a map of strings, such as selected headers, with each value redacted.
+type redactedMap struct {
+	redactor *redactor
+	values   map[string]string
+}
+
+func (m redactedMap) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for key, value := range m.values {
+		if m.redactor.denied(key) {
+			enc.AddString(key, m.redactor.value(key, value))
+			continue
+		}
+		enc.AddString(key, m.redactor.scrub(key, value))
+	}
+	return nil
+}
+
+func normalizeKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "-", "_")
+}
+
+func defaultRedactedKeys() []string {
+	return []string{
+		"password", "passwd", "secret", "token", "access_token", "refresh_token", "key", "api_key",
+		"authorization", "cookie", "set_cookie", "x_admin_token",
+		"email", "phone", "card_number", FieldUserAgent,
+	}
+}
+
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+	cardPattern   = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`)
+	phonePattern  = regexp.MustCompile(`\+\d[\d\s().\-]{6,}\d|\b\d{3}[\s.\-]\d{3}[\s.\-]\d{4}\b`)
+	// jsonFieldPattern matches "key": value for string and scalar values.
+	jsonFieldPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"\s*:\s*("(?:[^"\\]|\\.)*"?|[^,}\]\s]+)`)
+	formFieldPattern = regexp.MustCompile(`(^|&)([^=&]+)=([^&]*)`)
+)
+
+// keyMasks mask the values of denied keys whose format is known, keyed by normalized key.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var keyMasks = map[string]func(string) string{
+	"email": maskEmail,
+}
+
+// trustedKeys hold identifiers the service generates itself and are never scanned.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var trustedKeys = map[string]struct{}{
+	FieldService:    {},
+	FieldRequestID:  {},
+	FieldTraceID:    {},
+	FieldSpanID:     {},
+	FieldRoute:      {},
+	FieldMethod:     {},
+	FieldSuppressed: {},
+}
+
+const (
+	RedactMask = "mask"
+	RedactHash = "hash"
+)
+
+const (
+	redactedPlaceholder = "[REDACTED]"
+	maskRun             = "***"
+	hashPrefix          = "sha256:"
+	hashLength          = 16
+	minPhoneDigits      = 8
+	cardDigitsKept      = 4
+	phoneDigitsKept     = 2
+	maxDigit            = 9
+	jsonContentType     = "application/json"
+	formContentType     = "application/x-www-form-urlencoded"
+)
@@ -0,0 +1,168 @@
+package logger
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingConfig limits the volume of entries written under load. The zero value
+// disables sampling and deduplication.
+type SamplingConfig struct {
+	// Initial entries with the same level and message are written every second, then
+	// only every Thereafter-th one. Errors and above are never sampled.
+	Initial    int
+	Thereafter int
+	// Routes maps a route template to N: one in N of the Debug and Info entries logged
+	// for that route is written, e.g. to quiet health checks.
+	Routes map[string]int
+	// DedupWindow suppresses repeats of the same error for the window. The next entry
+	// written for that error carries the number of suppressed repeats.
+	DedupWindow time.Duration
+}
+
+// SamplingController is implemented by loggers whose sampling can change at runtime.
+type SamplingController interface {
+	SetSampling(cfg SamplingConfig)
+}
+
+// sampler decides which entries are dropped by sampling or deduplication.
+type sampler struct {
+	mu      sync.Mutex
+	config  SamplingConfig
+	now     func() time.Time
+	tickEnd time.Time
+	counts  map[string]int
+	routes  map[string]int
+	dedup   map[string]*dedupEntry
+}
+
+type dedupEntry struct {
+	since      time.Time
+	suppressed int
+}
+
+func newSampler() *sampler {
+	s := &sampler{now: time.Now}
+	s.reset()
+	return s
+}
+
+func (s *sampler) set(cfg SamplingConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = cfg
+	s.reset()
+}
+
+// reset must be called with mu held, or before the sampler is shared.
+func (s *sampler) reset() {
+	s.tickEnd = time.Time{}
+	s.counts = map[string]int{}
+	s.routes = map[string]int{}
+	s.dedup = map[string]*dedupEntry{}
+}
+
+// sample reports whether an entry with level and message should be written for ctx.
+func (s *sampler) sample(ctx context.Context, level zapcore.Level, message string) bool {
+	if level >= zap.ErrorLevel {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if level < zap.WarnLevel && !s.sampleRoute(routeFromContext(ctx), message) {
+		return false
+	}
+	if s.config.Initial <= 0 {
+		return true
+	}
+
+	now := s.now()
+	if now.After(s.tickEnd) {
+		s.counts = map[string]int{}
+		s.tickEnd = now.Add(samplingTick)
+	}
+	key := level.String() + keySeparator + message
+	s.counts[key]++
+	count := s.counts[key]
+	if count <= s.config.Initial {
+		return true
+	}
+	return s.config.Thereafter > 0 && (count-s.config.Initial)%s.config.Thereafter == 0
+}
+
+// sampleRoute must be called with mu held.
+func (s *sampler) sampleRoute(route, message string) bool {
+	n, ok := s.config.Routes[route]
+	if route == "" || !ok || n <= 1 {
+		return true
+	}
+	key := route + keySeparator + message
+	count := s.routes[key]
+	s.routes[key] = (count + 1) % n
+	return count == 0
+}
+
+// dedupe reports whether an error entry should be written and, if so, how many
+// identical entries were suppressed since it was last written.
+func (s *sampler) dedupe(level zapcore.Level, message string, fields []Field) (int, bool) {
+	if level != zap.ErrorLevel {
+		return 0, true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.config.DedupWindow <= 0 {
+		return 0, true
+	}
+
+	now := s.now()
+	key := message + keySeparator + errorText(fields)
+	entry, ok := s.dedup[key]
+	if ok && now.Sub(entry.since) < s.config.DedupWindow {
+		entry.suppressed++
+		return 0, false
+	}
+	suppressed := 0
+	if ok {
+		suppressed = entry.suppressed
+	}
+	s.dedup[key] = &dedupEntry{since: now}
+	if len(s.dedup) > maxDedupEntries {
+		s.evict(now)
+	}
+	return suppressed, true
+}
+
+// evict drops the entries whose window has passed, or all of them when that is not
+// enough to bound memory. It must be called with mu held.
+func (s *sampler) evict(now time.Time) {
+	for key, entry := range s.dedup {
+		if now.Sub(entry.since) >= s.config.DedupWindow {
+			delete(s.dedup, key)
+		}
+	}
+	if len(s.dedup) > maxDedupEntries {
+		s.dedup = map[string]*dedupEntry{}
+	}
+}
+
+func errorText(fields []Field) string {
+	var text []string
+	for _, field := range fields {
+		if err, ok := field.Value.(error); ok && err != nil {
+			text = append(text, err.Error())
+		}
+	}
+	return strings.Join(text, keySeparator)
+}
+
+const (
+	samplingTick    = time.Second
+	maxDedupEntries = 1024
+	keySeparator    = "\x00"
+)
@@ -0,0 +1,121 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newTestSampler(cfg SamplingConfig) (*sampler, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newSampler()
+	s.now = func() time.Time { return now }
+	s.set(cfg)
+	return s, &now
+}
+
+func TestSampler_InitialThenEveryThereafter(t *testing.T) {
+	s, _ := newTestSampler(SamplingConfig{Initial: 2, Thereafter: 3})
+	ctx := context.Background()
+
+	var written []int
+	for i := 1; i <= 8; i++ {
+		if s.sample(ctx, zap.InfoLevel, "Request started") {
+			written = append(written, i)
+		}
+	}
+
+	assert.Equal(t, []int{1, 2, 5, 8}, written)
+}
+
+func TestSampler_ResetsEverySecond(t *testing.T) {
+	s, now := newTestSampler(SamplingConfig{Initial: 1})
+	ctx := context.Background()
+
+	assert.True(t, s.sample(ctx, zap.InfoLevel, "m"))
+	assert.False(t, s.sample(ctx, zap.InfoLevel, "m"))
+	assert.True(t, s.sample(ctx, zap.InfoLevel, "other"))
+
+	*now = now.Add(2 * time.Second)
+	assert.True(t, s.sample(ctx, zap.InfoLevel, "m"))
+}
+
+func TestSampler_NeverSamplesErrors(t *testing.T) {
+	s, _ := newTestSampler(SamplingConfig{Initial: 1})
+	ctx := context.Background()
+
+	for range 5 {
+		assert.True(t, s.sample(ctx, zap.ErrorLevel, "boom"))
+	}
+}
+
+func TestSampler_RouteSampling(t *testing.T) {
+	s, _ := newTestSampler(SamplingConfig{Routes: map[string]int{"/health": 3}})
+	health := WithFields(context.Background(), String(FieldRoute, "/health"))
+	other := WithFields(context.Background(), String(FieldRoute, "/api/v1/user/:id"))
+
+	var written int
+	for range 9 {
+		if s.sample(health, zap.InfoLevel, "Request completed") {
+			written++
+		}
+		assert.True(t, s.sample(other, zap.InfoLevel, "Request completed"))
+	}
+
+	assert.Equal(t, 3, written)
+	assert.True(t, s.sample(health, zap.WarnLevel, "slow"))
+}
+
+func TestSampler_DedupeCountsSuppressedRepeats(t *testing.T) {
+	s, now := newTestSampler(SamplingConfig{DedupWindow: time.Minute})
+	fields := []Field{ErrorField(FieldError, errors.New("connection refused"))}
+
+	suppressed, ok := s.dedupe(zap.ErrorLevel, "redis failed", fields)
+	assert.True(t, ok)
+	assert.Zero(t, suppressed)
+	for range 4 {
+		_, ok = s.dedupe(zap.ErrorLevel, "redis failed", fields)
+		assert.False(t, ok)
+	}
+	_, ok = s.dedupe(zap.ErrorLevel, "redis failed", []Field{ErrorField(FieldError, errors.New("timeout"))})
+	assert.True(t, ok, "a different error is not a repeat")
+
+	*now = now.Add(time.Minute)
+	suppressed, ok = s.dedupe(zap.ErrorLevel, "redis failed", fields)
+	assert.True(t, ok)
+	assert.Equal(t, 4, suppressed)
+}
+
+func TestSampler_DedupeIgnoresOtherLevels(t *testing.T) {
+	s, _ := newTestSampler(SamplingConfig{DedupWindow: time.Minute})
+
+	for range 3 {
+		_, ok := s.dedupe(zap.WarnLevel, "w", nil)
+		assert.True(t, ok)
+	}
+}
+
+func TestZapLogger_SetSampling_AddsSuppressedField(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := newZapLogger(zap.New(core), newLevelManager(zapcore.DebugLevel))
+	l.SetSampling(SamplingConfig{DedupWindow: time.Minute})
+	now := time.Now()
+	l.sampler.now = func() time.Time { return now }
+	err := errors.New("boom")
+
+	for range 3 {
+		l.Error(context.Background(), "failed", ErrorField(FieldError, err))
+	}
+	now = now.Add(time.Minute)
+	l.Error(context.Background(), "failed", ErrorField(FieldError, err))
+
+	entries := logs.All()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, int64(2), entries[1].ContextMap()[FieldSuppressed])
+}
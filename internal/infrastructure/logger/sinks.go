@@ -0,0 +1,261 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/contrib/bridges/otelzap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// SinkConfig describes one destination for log entries. All sinks receive the same
+// entries, each filtered by its own minimum level.
+type SinkConfig struct {
+	// Type is SinkConsole, SinkJSON, SinkFile or SinkOTLP.
+	Type string
+	// Level is the minimum level written to this sink; "" writes every entry the
+	// logger's level lets through.
+	Level string
+	File  FileSinkConfig
+	OTLP  OTLPSinkConfig
+}
+
+// FileSinkConfig configures a JSON file rotated by size and, optionally, by time.
+type FileSinkConfig struct {
+	Path        string
+	MaxSizeMB   int
+	MaxBackups  int
+	MaxAgeDays  int
+	Compress    bool
+	RotateEvery time.Duration
+}
+
+// OTLPSinkConfig configures the export of log records over OTLP/gRPC. ServiceName and
+// Environment should match the trace resource so backends can join logs and traces.
+type OTLPSinkConfig struct {
+	Endpoint    string
+	Insecure    bool
+	ServiceName string
+	Environment string
+}
+
+// SinkController is implemented by loggers whose sinks can be replaced.
+type SinkController interface {
+	// SetSinks replaces the sinks entries are written to. The previous sinks are
+	// flushed and closed.
+	SetSinks(ctx context.Context, sinks []SinkConfig) error
+	// Shutdown flushes and closes the sinks.
+	Shutdown(ctx context.Context) error
+}
+
+// sinkSet is the zap logger built for a set of sinks and what must be closed with it.
+type sinkSet struct {
+	logger  *zap.Logger
+	closers []func(ctx context.Context) error
+}
+
+func (s *sinkSet) close(ctx context.Context) error {
+	errs := []error{ignoreSyncError(s.logger.Sync())}
+	for _, closeSink := range s.closers {
+		errs = append(errs, closeSink(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// DefaultSinks writes JSON to stdout, as the logger always did.
+func DefaultSinks() []SinkConfig {
+	return []SinkConfig{{Type: SinkJSON}}
+}
+
+// buildSinks tees the sinks into a single zap logger whose entries are first
+// filtered by floor, the lowest level any route may currently log at.
+func buildSinks(ctx context.Context, serviceName string, floor zapcore.LevelEnabler, sinks []SinkConfig) (*sinkSet, error) {
+	set := &sinkSet{}
+	cores := make([]zapcore.Core, 0, len(sinks))
+	for i := range sinks {
+		sink := &sinks[i]
+		core, closeSink, err := buildSink(ctx, serviceName, sink)
+		if err != nil {
+			set.logger = zap.NewNop()
+			return nil, errors.Join(err, set.close(ctx))
+		}
+		if closeSink != nil {
+			set.closers = append(set.closers, closeSink)
+		}
+		enabler, err := sinkLevel(floor, sink.Level)
+		if err != nil {
+			set.logger = zap.NewNop()
+			return nil, errors.Join(err, set.close(ctx))
+		}
+		cores = append(cores, &filteredCore{Core: core, enabler: enabler})
+	}
+
+	set.logger = zap.New(zapcore.NewTee(cores...),
+		zap.AddCaller(),
+		zap.AddCallerSkip(loggerCallerSkip),
+		zap.AddStacktrace(zap.ErrorLevel),
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+		zap.Fields(zap.String(FieldService, serviceName)),
+	)
+	return set, nil
+}
+
+func buildSink(ctx context.Context, serviceName string, sink *SinkConfig) (zapcore.Core, func(context.Context) error, error) {
+	switch sink.Type {
+	case SinkConsole:
+		encoderConfig := encoderConfig()
+		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		return zapcore.NewCore(zapcore.NewConsoleEncoder(encoderConfig), zapcore.Lock(os.Stdout), zap.DebugLevel), nil, nil
+	case SinkJSON:
+		return zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig()), zapcore.Lock(os.Stdout), zap.DebugLevel), nil, nil
+	case SinkFile:
+		return fileSink(sink.File)
+	case SinkOTLP:
+		return otlpSink(ctx, serviceName, sink.OTLP)
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownSink, sink.Type)
+	}
+}
+
+func fileSink(cfg FileSinkConfig) (zapcore.Core, func(context.Context) error, error) {
+	if cfg.Path == "" {
+		return nil, nil, fmt.Errorf("%w: file sink needs a path", ErrInvalidSink)
+	}
+	file := &lumberjack.Logger{
+		Filename:   cfg.Path,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     cfg.MaxAgeDays,
+		Compress:   cfg.Compress,
+	}
+	stop := make(chan struct{})
+	if cfg.RotateEvery > 0 {
+		go rotateEvery(file, cfg.RotateEvery, stop)
+	}
+	closeFile := func(context.Context) error {
+		close(stop)
+		return file.Close()
+	}
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig()), zapcore.AddSync(file), zap.DebugLevel)
+	return core, closeFile, nil
+}
+
+// rotateEvery starts a new file every interval, in addition to the size-based rotation.
+func rotateEvery(file *lumberjack.Logger, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := file.Rotate(); err != nil {
+				fmt.Fprintf(os.Stderr, "log file rotation failed: %v\n", err)
+			}
+		}
+	}
+}
+
+func otlpSink(ctx context.Context, serviceName string, cfg OTLPSinkConfig) (zapcore.Core, func(context.Context) error, error) {
+	options := []otlploggrpc.Option{}
+	if cfg.Endpoint != "" {
+		options = append(options, otlploggrpc.WithEndpoint(cfg.Endpoint))
+	}
+	if cfg.Insecure {
+		options = append(options, otlploggrpc.WithInsecure())
+	}
+	exporter, err := otlploggrpc.New(ctx, options...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create OTLP log exporter: %w", err)
+	}
+	if cfg.ServiceName != "" {
+		serviceName = cfg.ServiceName
+	}
+	attributes := []attribute.KeyValue{semconv.ServiceName(serviceName)}
+	if cfg.Environment != "" {
+		attributes = append(attributes, semconv.DeploymentEnvironment(cfg.Environment))
+	}
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+		sdklog.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attributes...)),
+	)
+	core := otelzap.NewCore(serviceName, otelzap.WithLoggerProvider(provider))
+	return core, provider.Shutdown, nil
+}
+
+func sinkLevel(floor zapcore.LevelEnabler, level string) (zapcore.LevelEnabler, error) {
+	if level == "" {
+		return floor, nil
+	}
+	minimum, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	return zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return l >= minimum && floor.Enabled(l)
+	}), nil
+}
+
+func encoderConfig() zapcore.EncoderConfig {
+	config := zap.NewProductionEncoderConfig()
+	config.TimeKey = FieldTimeStamp
+	config.EncodeTime = zapcore.ISO8601TimeEncoder
+	config.LevelKey = FieldSeverity
+	config.MessageKey = FieldMessage
+	config.CallerKey = FieldCaller
+	config.StacktraceKey = FieldStackTrace
+	return config
+}
+
+// ignoreSyncError drops the error fsync returns for terminals and pipes.
+func ignoreSyncError(err error) error {
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		return nil
+	}
+	return err
+}
+
+// filteredCore applies a sink's own level on top of the wrapped core.
+type filteredCore struct {
+	zapcore.Core
+	enabler zapcore.LevelEnabler
+}
+
+func (c *filteredCore) Enabled(level zapcore.Level) bool {
+	return c.enabler.Enabled(level) && c.Core.Enabled(level)
+}
+
+func (c *filteredCore) With(fields []zapcore.Field) zapcore.Core {
+	return &filteredCore{Core: c.Core.With(fields), enabler: c.enabler}
+}
+
+//nolint:gocritic // The signature is defined by zapcore.Core.
+func (c *filteredCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.enabler.Enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+const (
+	SinkConsole = "console"
+	SinkJSON    = "json"
+	SinkFile    = "file"
+	SinkOTLP    = "otlp"
+)
+
+var (
+	ErrUnknownSink = errors.New("unknown log sink")
+	ErrInvalidSink = errors.New("invalid log sink")
+)
@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func readLines(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		entry := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestSetSinks_FileSinksWithPerSinkLevels(t *testing.T) {
+	dir := t.TempDir()
+	all := filepath.Join(dir, "all.log")
+	errorsOnly := filepath.Join(dir, "errors.log")
+	l := newZapLogger(zap.NewNop(), newLevelManager(zapcore.DebugLevel))
+
+	require.NoError(t, l.SetSinks(context.Background(), []SinkConfig{
+		{Type: SinkFile, File: FileSinkConfig{Path: all}},
+		{Type: SinkFile, Level: ErrorKey, File: FileSinkConfig{Path: errorsOnly}},
+	}))
+	l.Debug(context.Background(), "debug entry")
+	l.Error(context.Background(), "error entry")
+	require.NoError(t, l.Shutdown(context.Background()))
+
+	allEntries := readLines(t, all)
+	require.Len(t, allEntries, 2)
+	assert.Equal(t, "debug entry", allEntries[0][FieldMessage])
+	errorEntries := readLines(t, errorsOnly)
+	require.Len(t, errorEntries, 1)
+	assert.Equal(t, "error entry", errorEntries[0][FieldMessage])
+	assert.Equal(t, "error", errorEntries[0][FieldSeverity])
+}
+
+func TestSetSinks_RespectsLoggerLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	l := newZapLogger(zap.NewNop(), newLevelManager(zapcore.WarnLevel))
+
+	require.NoError(t, l.SetSinks(context.Background(), []SinkConfig{{Type: SinkFile, Level: DebugKey, File: FileSinkConfig{Path: path}}}))
+	l.Info(context.Background(), "dropped")
+	l.Warn(context.Background(), "kept")
+	require.NoError(t, l.Shutdown(context.Background()))
+
+	entries := readLines(t, path)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "kept", entries[0][FieldMessage])
+}
+
+func TestSetSinks_FileRotatesOnInterval(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	l := newZapLogger(zap.NewNop(), newLevelManager(zapcore.InfoLevel))
+
+	require.NoError(t, l.SetSinks(context.Background(), []SinkConfig{{Type: SinkFile, File: FileSinkConfig{Path: path, RotateEvery: 20 * time.Millisecond}}}))
+	l.Info(context.Background(), "before rotation")
+
+	assert.Eventually(t, func() bool {
+		files, err := os.ReadDir(dir)
+		return err == nil && len(files) > 1
+	}, 2*time.Second, 10*time.Millisecond)
+	require.NoError(t, l.Shutdown(context.Background()))
+}
+
+func TestSetSinks_InvalidSinks(t *testing.T) {
+	l := newZapLogger(zap.NewNop(), newLevelManager(zapcore.InfoLevel))
+
+	assert.ErrorIs(t, l.SetSinks(context.Background(), []SinkConfig{{Type: "syslog"}}), ErrUnknownSink)
+	assert.ErrorIs(t, l.SetSinks(context.Background(), []SinkConfig{{Type: SinkFile}}), ErrInvalidSink)
+	assert.ErrorIs(t, l.SetSinks(context.Background(), []SinkConfig{{Type: SinkJSON, Level: "loud"}}), ErrUnknownLevel)
+}
+
+func TestSetSinks_ConsoleAndOTLP(t *testing.T) {
+	l := newZapLogger(zap.NewNop(), newLevelManager(zapcore.InfoLevel))
+
+	require.NoError(t, l.SetSinks(context.Background(), []SinkConfig{
+		{Type: SinkConsole},
+		{Type: SinkOTLP, OTLP: OTLPSinkConfig{Endpoint: "127.0.0.1:4317", Insecure: true, ServiceName: "svc-test"}},
+	}))
+	l.Info(context.Background(), "teed")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_ = l.Shutdown(ctx)
+}
+
+func TestFilteredCore_AppliesSinkLevel(t *testing.T) {
+	enabler, err := sinkLevel(zap.NewAtomicLevelAt(zapcore.DebugLevel), WarnKey)
+	require.NoError(t, err)
+	core := &filteredCore{Core: zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig()), zapcore.AddSync(io.Discard), zapcore.DebugLevel), enabler: enabler}
+
+	assert.False(t, core.Enabled(zapcore.InfoLevel))
+	assert.True(t, core.With(nil).Enabled(zapcore.ErrorLevel))
+}
+
+func TestSetSinks_ReportsCallerOfLogMethod(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "caller.log")
+	l := newZapLogger(zap.NewNop(), newLevelManager(zapcore.InfoLevel))
+
+	require.NoError(t, l.SetSinks(context.Background(), []SinkConfig{{Type: SinkFile, File: FileSinkConfig{Path: path}}}))
+	l.Info(context.Background(), "caller")
+	l.Infof(context.Background(), "caller %d", 2)
+	require.NoError(t, l.Shutdown(context.Background()))
+
+	for _, entry := range readLines(t, path) {
+		assert.Contains(t, entry[FieldCaller], "logger/sinks_test.go")
+	}
+}
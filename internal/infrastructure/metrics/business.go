@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go-service-template/internal/usecase/limit"
+	"go-service-template/internal/usecase/user"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Instrumentation records the business events of the use cases as Prometheus metrics.
+type Instrumentation struct {
+	limitChecks      *prometheus.CounterVec
+	limitResets      *prometheus.CounterVec
+	policyOverrides  *prometheus.CounterVec
+	scriptDuration   *prometheus.HistogramVec
+	userCreations    prometheus.Counter
+	userCacheLookups *prometheus.CounterVec
+}
+
+var (
+	_ limit.Instrumentation = (*Instrumentation)(nil)
+	_ user.Instrumentation  = (*Instrumentation)(nil)
+)
+
+// NewInstrumentation registers the business metrics with the registry of m.
+func NewInstrumentation(m *Metrics) *Instrumentation {
+	i := &Instrumentation{
+		limitChecks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "limit_checks_total",
+			Help: "Number of limit checks, by policy and whether the request was within the limit.",
+		}, []string{LabelPolicy, LabelResult}),
+		limitResets: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "limit_resets_total",
+			Help: "Number of limit window resets, by policy.",
+		}, []string{LabelPolicy}),
+		policyOverrides: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "limit_policy_overrides_total",
+			Help: "Number of limit checks naming a policy instead of using the default one.",
+		}, []string{LabelPolicy}),
+		scriptDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "redis_script_duration_seconds",
+			Help:    "Time spent running Redis scripts.",
+			Buckets: prometheus.ExponentialBuckets(scriptBucketStart, scriptBucketFactor, scriptBucketCount),
+		}, []string{LabelScript}),
+		userCreations: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "user_creations_total",
+			Help: "Number of users created.",
+		}),
+		userCacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "user_cache_lookups_total",
+			Help: "Number of user cache lookups, by hit or miss.",
+		}, []string{LabelResult}),
+	}
+	m.registry.MustRegister(i.limitChecks, i.limitResets, i.policyOverrides, i.scriptDuration, i.userCreations, i.userCacheLookups)
+	return i
+}
+
+func (i *Instrumentation) LimitChecked(_ context.Context, policy string, allowed bool) {
+	result := ResultDenied
+	if allowed {
+		result = ResultAllowed
+	}
+	i.limitChecks.WithLabelValues(policy, result).Inc()
+}
+
+func (i *Instrumentation) LimitReset(_ context.Context, policy string) {
+	i.limitResets.WithLabelValues(policy).Inc()
+}
+
+func (i *Instrumentation) PolicyOverridden(_ context.Context, policy string) {
+	i.policyOverrides.WithLabelValues(policy).Inc()
+}
+
+func (i *Instrumentation) ScriptExecuted(_ context.Context, script string, duration time.Duration) {
+	i.scriptDuration.WithLabelValues(script).Observe(duration.Seconds())
+}
+
+func (i *Instrumentation) UserCreated(context.Context) {
+	i.userCreations.Inc()
+}
+
+func (i *Instrumentation) UserCacheLookup(_ context.Context, hit bool) {
+	result := ResultMiss
+	if hit {
+		result = ResultHit
+	}
+	i.userCacheLookups.WithLabelValues(result).Inc()
+}
+
+const (
+	LabelPolicy   = "policy"
+	LabelResult   = "result"
+	LabelScript   = "script"
+	ResultAllowed = "allowed"
+	ResultDenied  = "denied"
+	ResultHit     = "hit"
+	ResultMiss    = "miss"
+)
+
+// Redis scripts run in well under a millisecond; buckets span 100µs to about 400ms.
+const (
+	scriptBucketStart  = 0.0001
+	scriptBucketFactor = 2
+	scriptBucketCount  = 12
+)
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstrumentation_RecordsLimitEvents(t *testing.T) {
+	i := NewInstrumentation(NewMetrics())
+	ctx := context.Background()
+
+	i.LimitChecked(ctx, "default", true)
+	i.LimitChecked(ctx, "default", true)
+	i.LimitChecked(ctx, "default", false)
+	i.LimitReset(ctx, "default")
+	i.PolicyOverridden(ctx, "tight")
+	i.ScriptExecuted(ctx, "fixed_window", time.Millisecond)
+
+	assert.InDelta(t, 2, testutil.ToFloat64(i.limitChecks.WithLabelValues("default", ResultAllowed)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(i.limitChecks.WithLabelValues("default", ResultDenied)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(i.limitResets.WithLabelValues("default")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(i.policyOverrides.WithLabelValues("tight")), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(i.scriptDuration))
+}
+
+func TestInstrumentation_RecordsUserEvents(t *testing.T) {
+	i := NewInstrumentation(NewMetrics())
+	ctx := context.Background()
+
+	i.UserCreated(ctx)
+	i.UserCacheLookup(ctx, true)
+	i.UserCacheLookup(ctx, false)
+	i.UserCacheLookup(ctx, false)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(i.userCreations), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(i.userCacheLookups.WithLabelValues(ResultHit)), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(i.userCacheLookups.WithLabelValues(ResultMiss)), 0)
+}
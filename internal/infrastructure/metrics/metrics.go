@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the Prometheus registry of the service and its HTTP RED metrics:
+// request rate, errors (by status) and duration, labeled by route template.
+type Metrics struct {
+	registry *prometheus.Registry
+	// otel holds the metrics of OpenTelemetry instruments. It is kept apart from registry
+	// so bridging registry to OTLP does not export them twice.
+	otel     *prometheus.Registry
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+}
+
+// NewMetrics creates a registry with the HTTP metrics and the Go runtime and process
+// collectors. Each instance has its own registry, so routers built in tests do not clash.
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		otel:     prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Number of HTTP requests handled.",
+		}, []string{LabelRoute, LabelMethod, LabelStatus}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Time spent handling HTTP requests.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{LabelRoute, LabelMethod, LabelStatus}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "Number of HTTP requests being handled.",
+		}, []string{LabelRoute, LabelMethod}),
+	}
+	m.registry.MustRegister(
+		m.requests,
+		m.duration,
+		m.inFlight,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Registry returns the registry the metrics are registered with, for additional collectors.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// OTelRegisterer returns the registerer for the Prometheus exporter of the OpenTelemetry
+// MeterProvider. Its metrics are served by Handler alongside those of Registry.
+func (m *Metrics) OTelRegisterer() prometheus.Registerer {
+	return m.otel
+}
+
+// Handler serves the registered metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(prometheus.Gatherers{m.registry, m.otel}, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Middleware records the RED metrics of every request. Requests matching no route are
+// grouped under UnmatchedRoute so arbitrary paths cannot inflate the label cardinality.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = UnmatchedRoute
+		}
+		method := c.Request.Method
+		inFlight := m.inFlight.WithLabelValues(route, method)
+		inFlight.Inc()
+		start := time.Now()
+
+		c.Next()
+
+		inFlight.Dec()
+		status := strconv.Itoa(c.Writer.Status())
+		m.requests.WithLabelValues(route, method, status).Inc()
+		m.duration.WithLabelValues(route, method, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+const (
+	LabelRoute     = "route"
+	LabelMethod    = "method"
+	LabelStatus    = "status"
+	UnmatchedRoute = "unmatched"
+)
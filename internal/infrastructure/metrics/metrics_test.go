@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMetricsRouter(m *Metrics) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(m.Middleware())
+	r.GET("/api/v1/user/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/metrics", gin.WrapH(m.Handler()))
+	return r
+}
+
+func TestMiddleware_RecordsRouteTemplateMethodAndStatus(t *testing.T) {
+	m := NewMetrics()
+	r := newMetricsRouter(m)
+
+	for _, path := range []string{"/api/v1/user/1", "/api/v1/user/2", "/missing"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.InDelta(t, 2, testutil.ToFloat64(m.requests.WithLabelValues("/api/v1/user/:id", http.MethodGet, "200")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.requests.WithLabelValues(UnmatchedRoute, http.MethodGet, "404")), 0)
+	assert.Equal(t, 2, testutil.CollectAndCount(m.duration))
+	assert.InDelta(t, 0, testutil.ToFloat64(m.inFlight.WithLabelValues("/api/v1/user/:id", http.MethodGet)), 0)
+}
+
+func TestHandler_ExposesHTTPAndRuntimeMetrics(t *testing.T) {
+	r := newMetricsRouter(NewMetrics())
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/user/1", nil))
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/api/v1/user/:id",status="200"} 1`)
+	assert.Contains(t, body, "http_request_duration_seconds_bucket")
+	assert.Contains(t, body, "http_requests_in_flight")
+	assert.Contains(t, body, "go_goroutines")
+	assert.Contains(t, body, "process_start_time_seconds")
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisPoolCollector reads the Redis connection pool statistics on every collection, so
+// they are current on each /metrics scrape and each periodic OTLP push.
+type redisPoolCollector struct {
+	stats    func() *redis.PoolStats
+	hits     *prometheus.Desc
+	misses   *prometheus.Desc
+	timeouts *prometheus.Desc
+	stale    *prometheus.Desc
+	conns    *prometheus.Desc
+}
+
+// RegisterRedisPool registers the statistics returned by stats with the registry of m.
+// Pool exhaustion shows as timeouts growing while idle connections stay at zero.
+func RegisterRedisPool(m *Metrics, stats func() *redis.PoolStats) {
+	m.registry.MustRegister(&redisPoolCollector{
+		stats: stats,
+		hits: prometheus.NewDesc("redis_pool_hits_total",
+			"Number of times a free connection was found in the Redis pool.", nil, nil),
+		misses: prometheus.NewDesc("redis_pool_misses_total",
+			"Number of times a free connection was not found in the Redis pool.", nil, nil),
+		timeouts: prometheus.NewDesc("redis_pool_timeouts_total",
+			"Number of times waiting for a Redis pool connection timed out.", nil, nil),
+		stale: prometheus.NewDesc("redis_pool_stale_connections_total",
+			"Number of stale Redis connections removed from the pool.", nil, nil),
+		conns: prometheus.NewDesc("redis_pool_connections",
+			"Number of connections in the Redis pool, by state.", []string{LabelState}, nil),
+	})
+}
+
+func (c *redisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.timeouts
+	ch <- c.stale
+	ch <- c.conns
+}
+
+func (c *redisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+	if stats == nil {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(c.stale, prometheus.CounterValue, float64(stats.StaleConns))
+	ch <- prometheus.MustNewConstMetric(c.conns, prometheus.GaugeValue, float64(stats.TotalConns), StateTotal)
+	ch <- prometheus.MustNewConstMetric(c.conns, prometheus.GaugeValue, float64(stats.IdleConns), StateIdle)
+}
+
+const (
+	LabelState = "state"
+	StateTotal = "total"
+	StateIdle  = "idle"
+)
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterRedisPool_ExportsPoolStats(t *testing.T) {
+	m := NewMetrics()
+	stats := &redis.PoolStats{Hits: 10, Misses: 2, Timeouts: 1, StaleConns: 3, TotalConns: 5, IdleConns: 4}
+	RegisterRedisPool(m, func() *redis.PoolStats { return stats })
+
+	expected := `
+# HELP redis_pool_connections Number of connections in the Redis pool, by state.
+# TYPE redis_pool_connections gauge
+redis_pool_connections{state="idle"} 4
+redis_pool_connections{state="total"} 5
+# HELP redis_pool_hits_total Number of times a free connection was found in the Redis pool.
+# TYPE redis_pool_hits_total counter
+redis_pool_hits_total 10
+# HELP redis_pool_misses_total Number of times a free connection was not found in the Redis pool.
+# TYPE redis_pool_misses_total counter
+redis_pool_misses_total 2
+# HELP redis_pool_timeouts_total Number of times waiting for a Redis pool connection timed out.
+# TYPE redis_pool_timeouts_total counter
+redis_pool_timeouts_total 1
+`
+	err := testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected),
+		"redis_pool_connections", "redis_pool_hits_total", "redis_pool_misses_total", "redis_pool_timeouts_total")
+	require.NoError(t, err)
+
+	stats.Timeouts = 4
+	count, err := testutil.GatherAndCount(m.Registry(), "redis_pool_timeouts_total")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.NoError(t, testutil.GatherAndCompare(m.Registry(), strings.NewReader(`
+# HELP redis_pool_timeouts_total Number of times waiting for a Redis pool connection timed out.
+# TYPE redis_pool_timeouts_total counter
+redis_pool_timeouts_total 4
+`), "redis_pool_timeouts_total"), "stats are read on every collection")
+}
+
+func TestRegisterRedisPool_NoStats_ExportsNothing(t *testing.T) {
+	m := NewMetrics()
+	RegisterRedisPool(m, func() *redis.PoolStats { return nil })
+
+	count, err := testutil.GatherAndCount(m.Registry(), "redis_pool_hits_total", "redis_pool_connections")
+
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,28 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyBuilder_Key_NamespacesByAppEnvVersionAndTenant(t *testing.T) {
+	keys := NewKeyBuilder("limiter", "prod")
+
+	assert.Equal(t, "limiter:prod:v1:acme:limit:{7}:default", keys.Key("acme", "limit", HashTag("7"), "default"))
+	assert.Equal(t, "limiter:prod:v1:default:user:7", keys.Key("", "user", "7"))
+}
+
+func TestKeyBuilder_Version_BuildsOtherSchema(t *testing.T) {
+	keys := NewKeyBuilder("limiter", "prod")
+
+	assert.Equal(t, "limiter:prod:v2:acme:user:7", keys.Version(2).Key("acme", "user", "7"))
+	assert.Equal(t, "limiter:prod:v1:acme:", keys.Prefix("acme"), "Version does not change the receiver")
+}
+
+func TestKeyBuilder_SanitizesNamespace(t *testing.T) {
+	keys := NewKeyBuilder("my:app", "{prod}")
+
+	assert.Equal(t, "my_app:prod:v1:a_b:user:7", keys.Key("a:b", "user", "7"),
+		"separators and braces in the namespace would make keys ambiguous or share a hash tag")
+}
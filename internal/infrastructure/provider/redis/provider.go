@@ -2,51 +2,194 @@ package redis
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
-	"time"
+	"os"
+	"sync/atomic"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 
 	"go-service-template/internal/infrastructure/config"
 	"go-service-template/internal/infrastructure/logger"
 )
 
+// Provider owns the Redis client of the configured mode. Callers use the
+// redis.UniversalClient interface, so they run unchanged against a single node, a
+// Sentinel-monitored master or a cluster.
 type Provider struct {
-	client *redis.Client
+	client redis.UniversalClient
+	keys   KeyBuilder
+	// ready holds the outcome of the last ping, made by the startup phase and the
+	// readiness probe.
+	ready atomic.Bool
 }
 
+// NewProvider creates the Redis client without connecting: connections are dialed on
+// first use, and the startup phase waits for Redis by pinging it.
 func NewProvider(cfg config.Provider) (*Provider, error) {
 	ctx := context.Background()
-	logger.Info(ctx, "Connecting to Redis",
-		logger.String("host", cfg.GetRedisHost()),
-	)
+	redisConfig := cfg.GetRedisConfig()
+	logger.GetGlobalLogger().Named(componentName).Info(ctx, "Creating Redis client", settingsFields(&redisConfig)...)
 
-	client := redis.NewClient(&redis.Options{
-		Addr:     cfg.GetRedisHost(),
-		PoolSize: poolSize,
-	})
-
-	pingCtx, cancel := context.WithTimeout(context.Background(), contextTimeout)
-	defer cancel()
+	options, err := clientOptions(&redisConfig)
+	if err != nil {
+		return nil, err
+	}
+	client := newClient(redisConfig.Mode, options)
+	if redisConfig.SlowCommandThreshold > 0 {
+		client.AddHook(slowCommandHook{threshold: redisConfig.SlowCommandThreshold})
+	}
 
-	if err := client.Ping(pingCtx).Err(); err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	// Every command becomes a child span of the caller's span.
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to instrument Redis tracing: %w", err)
 	}
 
 	return &Provider{
 		client: client,
+		keys:   NewKeyBuilder(cfg.GetAppName(), cfg.GetEnv()),
 	}, nil
 }
 
-func (p *Provider) GetClient() *redis.Client {
+// newClient creates the client of mode. Unlike redis.NewUniversalClient, it does not guess
+// the mode from the number of addresses, so a cluster seeded with one node stays a cluster.
+func newClient(mode string, options *redis.UniversalOptions) redis.UniversalClient {
+	switch mode {
+	case config.RedisModeSentinel:
+		return redis.NewFailoverClient(options.Failover())
+	case config.RedisModeCluster:
+		return redis.NewClusterClient(options.Cluster())
+	default:
+		return redis.NewClient(options.Simple())
+	}
+}
+
+func clientOptions(cfg *config.RedisConfig) (*redis.UniversalOptions, error) {
+	options := &redis.UniversalOptions{
+		Addrs:            cfg.Addresses(),
+		MasterName:       cfg.MasterName,
+		SentinelUsername: cfg.SentinelUsername,
+		SentinelPassword: cfg.SentinelPassword,
+		Username:         cfg.Username,
+		Password:         cfg.Password,
+		DB:               cfg.DB,
+		DialTimeout:      cfg.DialTimeout,
+		ReadTimeout:      cfg.ReadTimeout,
+		WriteTimeout:     cfg.WriteTimeout,
+		PoolSize:         cfg.PoolSize,
+		MinIdleConns:     cfg.MinIdleConns,
+		MaxRetries:       cfg.MaxRetries,
+	}
+	if cfg.TLS.Enabled {
+		tlsConfig, err := newTLSConfig(&cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		options.TLSConfig = tlsConfig
+	}
+	return options, nil
+}
+
+// newTLSConfig loads the CA and client certificate files. Without a CA file the system
+// roots verify the server.
+func newTLSConfig(cfg *config.RedisTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+	}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read Redis CA file: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCAFile, cfg.CAFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+	if cfg.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load Redis client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+// settingsFields describes the client settings for the logs, without the password.
+func settingsFields(cfg *config.RedisConfig) []logger.Field {
+	return []logger.Field{
+		logger.String("mode", cfg.Mode),
+		logger.Any("addrs", cfg.Addresses()),
+		logger.String("master_name", cfg.MasterName),
+		logger.String("username", cfg.Username),
+		logger.String("password", redact(cfg.Password)),
+		logger.String("sentinel_password", redact(cfg.SentinelPassword)),
+		logger.Int("db", cfg.DB),
+		logger.Bool("tls", cfg.TLS.Enabled),
+		logger.String("dial_timeout", cfg.DialTimeout.String()),
+		logger.String("read_timeout", cfg.ReadTimeout.String()),
+		logger.String("write_timeout", cfg.WriteTimeout.String()),
+		logger.Int("pool_size", cfg.PoolSize),
+		logger.Int("min_idle_conns", cfg.MinIdleConns),
+		logger.Int("max_retries", cfg.MaxRetries),
+		logger.String("slow_command_threshold", cfg.SlowCommandThreshold.String()),
+	}
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redactedPassword
+}
+
+func (p *Provider) GetClient() redis.UniversalClient {
 	return p.client
 }
 
+// Keys returns the builder of the keys of the service. Every key read or written through
+// the client must come from it.
+func (p *Provider) Keys() KeyBuilder {
+	return p.keys
+}
+
+// PoolStats returns the connection pool statistics, summed over every node in cluster mode.
+func (p *Provider) PoolStats() *redis.PoolStats {
+	return p.client.PoolStats()
+}
+
+// Ping checks that Redis answers, for the startup phase and the readiness probe. In
+// cluster mode every master must answer, as any of them may own the keys of a request.
+func (p *Provider) Ping(ctx context.Context) error {
+	var err error
+	if cluster, ok := p.client.(*redis.ClusterClient); ok {
+		err = cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return node.Ping(ctx).Err()
+		})
+	} else {
+		err = p.client.Ping(ctx).Err()
+	}
+	p.ready.Store(err == nil)
+	return err
+}
+
+// Ready reports whether the last ping succeeded. It is false for a nil provider and
+// before the first ping, so callers serve without Redis until it connects.
+func (p *Provider) Ready() bool {
+	return p != nil && p.ready.Load()
+}
+
 func (p *Provider) Close() error {
 	return p.client.Close()
 }
 
-const (
-	contextTimeout = 5 * time.Second
-	poolSize       = 10
-)
+const redactedPassword = "[REDACTED]"
+
+var ErrInvalidCAFile = errors.New("no certificate found in Redis CA file")
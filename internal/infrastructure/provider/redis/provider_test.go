@@ -1,13 +1,29 @@
 package redis
 
 import (
+	"context"
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"go-service-template/internal/infrastructure/config"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-type fakeCfg struct{ host string }
+type fakeCfg struct {
+	config.Provider
+	host string
+	mode string
+
+	slowCommandThreshold time.Duration
+}
 
 func (f fakeCfg) GetServerHost() string                { return "" }
 func (f fakeCfg) GetServerPort() string                { return "" }
@@ -18,14 +34,144 @@ func (f fakeCfg) GetEnv() string                       { return "" }
 func (f fakeCfg) GetAppName() string                   { return "" }
 func (f fakeCfg) GetNRLicenseKey() string              { return "" }
 func (f fakeCfg) GetOTLPEndpoint() string              { return "" }
+func (f fakeCfg) GetRedisConfig() config.RedisConfig {
+	return config.RedisConfig{Mode: f.mode, Host: f.host, PoolSize: 1, SlowCommandThreshold: f.slowCommandThreshold}
+}
 
-func TestNewProvider_InvalidHost_ReturnsError(t *testing.T) {
+func TestNewProvider_InvalidHost_FailsOnPing(t *testing.T) {
 	cfg := fakeCfg{host: "127.0.0.1:0"}
-	_, err := NewProvider(cfg)
-	assert.Error(t, err, "expected error for invalid redis host")
+	p, err := NewProvider(cfg)
+	require.NoError(t, err, "the client connects lazily")
+	defer p.Close()
+	assert.Error(t, p.Ping(context.Background()), "expected error for invalid redis host")
+	assert.False(t, p.Ready())
+}
+
+func TestProvider_Ready_AfterPing(t *testing.T) {
+	mr := miniredis.RunT(t)
+	p, err := NewProvider(fakeCfg{host: mr.Addr()})
+	require.NoError(t, err)
+	defer p.Close()
+	assert.False(t, p.Ready(), "not ready before the first ping")
+
+	require.NoError(t, p.Ping(context.Background()))
+	assert.True(t, p.Ready())
+
+	mr.Close()
+	assert.Error(t, p.Ping(context.Background()))
+	assert.False(t, p.Ready(), "a failed ping marks Redis unavailable again")
+}
+
+func TestProvider_Ready_NilProvider(t *testing.T) {
+	var p *Provider
+	assert.False(t, p.Ready())
 }
 
 func TestProvider_GetClient_NilUntilConnected(t *testing.T) {
 	p := &Provider{client: nil}
 	assert.Nil(t, p.GetClient())
 }
+
+func TestClientOptions_MapsSettings(t *testing.T) {
+	cfg := config.RedisConfig{
+		Host: "redis:6379", Username: "app", Password: "s3cret", DB: 2,
+		DialTimeout: time.Second, ReadTimeout: 2 * time.Second, WriteTimeout: 3 * time.Second,
+		PoolSize: 20, MinIdleConns: 5, MaxRetries: -1,
+	}
+
+	options, err := clientOptions(&cfg)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"redis:6379"}, options.Addrs)
+	assert.Equal(t, "app", options.Username)
+	assert.Equal(t, "s3cret", options.Password)
+	assert.Equal(t, 2, options.DB)
+	assert.Equal(t, 20, options.PoolSize)
+	assert.Equal(t, 5, options.MinIdleConns)
+	assert.Equal(t, -1, options.MaxRetries)
+	assert.Equal(t, 3*time.Second, options.WriteTimeout)
+	assert.Nil(t, options.TLSConfig)
+}
+
+func TestClientOptions_SentinelSettings(t *testing.T) {
+	cfg := config.RedisConfig{
+		Mode: config.RedisModeSentinel, Host: "ignored:6379", Addrs: []string{"sentinel-1:26379", "sentinel-2:26379"},
+		MasterName: "mymaster", SentinelUsername: "watcher", SentinelPassword: "sentinel-secret", PoolSize: 1,
+	}
+
+	options, err := clientOptions(&cfg)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sentinel-1:26379", "sentinel-2:26379"}, options.Addrs)
+	assert.Equal(t, "mymaster", options.Failover().MasterName)
+	assert.Equal(t, "watcher", options.Failover().SentinelUsername)
+	assert.Equal(t, "sentinel-secret", options.Failover().SentinelPassword)
+}
+
+func TestNewClient_ModeSelectsClient(t *testing.T) {
+	options := &goredis.UniversalOptions{Addrs: []string{"redis:6379"}, MasterName: "mymaster"}
+	tests := []struct {
+		mode string
+		want goredis.UniversalClient
+	}{
+		{config.RedisModeStandalone, &goredis.Client{}},
+		{config.RedisModeSentinel, &goredis.Client{}},
+		{config.RedisModeCluster, &goredis.ClusterClient{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			client := newClient(tt.mode, options)
+			defer client.Close()
+			assert.IsType(t, tt.want, client)
+		})
+	}
+}
+
+func TestProvider_Ping_ClusterMode(t *testing.T) {
+	mr := miniredis.RunT(t)
+	p, err := NewProvider(fakeCfg{host: mr.Addr(), mode: config.RedisModeCluster})
+	require.NoError(t, err)
+	defer p.Close()
+
+	require.NoError(t, p.Ping(context.Background()))
+	assert.True(t, p.Ready())
+	assert.IsType(t, &goredis.ClusterClient{}, p.GetClient())
+}
+
+func TestClientOptions_TLSWithCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0o600))
+	cfg := config.RedisConfig{Host: "redis:6380", PoolSize: 1, TLS: config.RedisTLSConfig{Enabled: true, CAFile: caFile, ServerName: "redis"}}
+
+	options, err := clientOptions(&cfg)
+
+	require.NoError(t, err)
+	require.NotNil(t, options.TLSConfig)
+	assert.Equal(t, "redis", options.TLSConfig.ServerName)
+	assert.NotNil(t, options.TLSConfig.RootCAs)
+}
+
+func TestClientOptions_InvalidCAFile(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+	cfg := config.RedisConfig{TLS: config.RedisTLSConfig{Enabled: true, CAFile: caFile}}
+
+	_, err := clientOptions(&cfg)
+
+	assert.ErrorIs(t, err, ErrInvalidCAFile)
+}
+
+func TestSettingsFields_RedactsPassword(t *testing.T) {
+	cfg := config.RedisConfig{Host: "redis:6379", Password: "s3cret", SentinelPassword: "s3cret"}
+
+	for _, field := range settingsFields(&cfg) {
+		if field.Key == "password" || field.Key == "sentinel_password" {
+			assert.Equal(t, redactedPassword, field.Value)
+			continue
+		}
+		assert.NotEqual(t, "s3cret", field.Value, field.Key)
+	}
+}
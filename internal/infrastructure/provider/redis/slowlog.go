@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"go-service-template/internal/infrastructure/logger"
+)
+
+// slowCommandHook logs the commands and pipelines taking longer than threshold. Entries
+// are logged with the caller's context, so they carry its request ID and trace. Command
+// arguments are left out as they hold keys and values.
+type slowCommandHook struct {
+	threshold time.Duration
+}
+
+var _ redis.Hook = slowCommandHook{}
+
+func (h slowCommandHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (h slowCommandHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		h.logIfSlow(ctx, cmd.FullName(), time.Since(start))
+		return err
+	}
+}
+
+func (h slowCommandHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		names := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			names[i] = cmd.FullName()
+		}
+		h.logIfSlow(ctx, pipelinePrefix+strings.Join(names, " "), time.Since(start))
+		return err
+	}
+}
+
+func (h slowCommandHook) logIfSlow(ctx context.Context, command string, elapsed time.Duration) {
+	if elapsed < h.threshold {
+		return
+	}
+	logger.FromContext(ctx).Named(componentName).Warn(ctx, "Slow Redis command",
+		logger.String(fieldCommand, command),
+		logger.Int64(logger.FieldDuration, elapsed.Milliseconds()),
+		logger.Int64(fieldThreshold, h.threshold.Milliseconds()),
+	)
+}
+
+const (
+	componentName  = "redis"
+	fieldCommand   = "command"
+	fieldThreshold = "threshold"
+	pipelinePrefix = "pipeline: "
+)
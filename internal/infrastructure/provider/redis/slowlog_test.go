@@ -0,0 +1,98 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-service-template/internal/infrastructure/logger"
+	loggermocks "go-service-template/internal/infrastructure/logger/mocks"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newHookedClient(t *testing.T, threshold time.Duration) *goredis.Client {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	client.AddHook(slowCommandHook{threshold: threshold})
+	// Open the connection first: its setup commands go through the hook too.
+	require.NoError(t, client.Ping(context.Background()).Err())
+	return client
+}
+
+func fieldValue(fields []logger.Field, key string) interface{} {
+	for _, field := range fields {
+		if field.Key == key {
+			return field.Value
+		}
+	}
+	return nil
+}
+
+func TestSlowCommandHook_SlowCommand_LogsWithRequestID(t *testing.T) {
+	client := newHookedClient(t, time.Nanosecond)
+	log := loggermocks.NewLogger(t)
+	ctx := logger.WithLogger(logger.WithFields(context.Background(), logger.String(logger.FieldRequestID, "req-1")), log)
+	var logCtx context.Context
+	var fields []logger.Field
+	log.EXPECT().Named(componentName).Return(log).Once()
+	log.EXPECT().Warn(mock.Anything, "Slow Redis command", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, _ string, f ...logger.Field) {
+			logCtx = ctx
+			fields = f
+		}).Return().Once()
+
+	require.NoError(t, client.Set(ctx, "user:{7}", "cached", 0).Err())
+
+	assert.Equal(t, "req-1", logger.RequestIDFromContext(logCtx))
+	assert.Equal(t, "set", fieldValue(fields, fieldCommand))
+	assert.NotContains(t, fieldValue(fields, fieldCommand), "cached", "arguments are not logged")
+}
+
+func TestSlowCommandHook_SlowPipeline_LogsCommands(t *testing.T) {
+	client := newHookedClient(t, time.Nanosecond)
+	log := loggermocks.NewLogger(t)
+	ctx := logger.WithLogger(context.Background(), log)
+	var fields []logger.Field
+	log.EXPECT().Named(componentName).Return(log).Once()
+	log.EXPECT().Warn(mock.Anything, "Slow Redis command", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ string, f ...logger.Field) { fields = f }).Return().Once()
+
+	_, err := client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Incr(ctx, "counter")
+		pipe.Expire(ctx, "counter", time.Minute)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "pipeline: incr expire", fieldValue(fields, fieldCommand))
+}
+
+func TestSlowCommandHook_FastCommand_NotLogged(t *testing.T) {
+	client := newHookedClient(t, time.Hour)
+	log := loggermocks.NewLogger(t)
+	ctx := logger.WithLogger(context.Background(), log)
+
+	require.NoError(t, client.Ping(ctx).Err())
+}
+
+func TestNewProvider_SlowCommandThreshold_InstallsHook(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cfg := fakeCfg{host: mr.Addr(), slowCommandThreshold: time.Nanosecond}
+	p, err := NewProvider(cfg)
+	require.NoError(t, err)
+	defer p.Close()
+	require.NoError(t, p.GetClient().Ping(context.Background()).Err())
+	log := loggermocks.NewLogger(t)
+	log.EXPECT().Named(componentName).Return(log).Once()
+	log.EXPECT().Warn(mock.Anything, "Slow Redis command", mock.Anything, mock.Anything, mock.Anything).Return().Once()
+
+	require.NoError(t, p.Ping(logger.WithLogger(context.Background(), log)))
+	assert.Equal(t, uint32(1), p.PoolStats().TotalConns)
+}
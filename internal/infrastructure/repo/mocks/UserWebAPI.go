@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	"context"
 	"go-service-template/internal/domain/user"
 
 	mock "github.com/stretchr/testify/mock"
@@ -38,8 +39,8 @@ func (_m *UserWebAPI) EXPECT() *UserWebAPI_Expecter {
 }
 
 // Fetch provides a mock function for the type UserWebAPI
-func (_mock *UserWebAPI) Fetch(n int) (user.User, error) {
-	ret := _mock.Called(n)
+func (_mock *UserWebAPI) Fetch(ctx context.Context, tenantID string, id int) (user.User, error) {
+	ret := _mock.Called(ctx, tenantID, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Fetch")
@@ -47,16 +48,16 @@ func (_mock *UserWebAPI) Fetch(n int) (user.User, error) {
 
 	var r0 user.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int) (user.User, error)); ok {
-		return returnFunc(n)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) (user.User, error)); ok {
+		return returnFunc(ctx, tenantID, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int) user.User); ok {
-		r0 = returnFunc(n)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) user.User); ok {
+		r0 = returnFunc(ctx, tenantID, id)
 	} else {
 		r0 = ret.Get(0).(user.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
-		r1 = returnFunc(n)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, tenantID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -69,19 +70,31 @@ type UserWebAPI_Fetch_Call struct {
 }
 
 // Fetch is a helper method to define mock.On call
-//   - n int
-func (_e *UserWebAPI_Expecter) Fetch(n interface{}) *UserWebAPI_Fetch_Call {
-	return &UserWebAPI_Fetch_Call{Call: _e.mock.On("Fetch", n)}
+//   - ctx context.Context
+//   - tenantID string
+//   - id int
+func (_e *UserWebAPI_Expecter) Fetch(ctx interface{}, tenantID interface{}, id interface{}) *UserWebAPI_Fetch_Call {
+	return &UserWebAPI_Fetch_Call{Call: _e.mock.On("Fetch", ctx, tenantID, id)}
 }
 
-func (_c *UserWebAPI_Fetch_Call) Run(run func(n int)) *UserWebAPI_Fetch_Call {
+func (_c *UserWebAPI_Fetch_Call) Run(run func(ctx context.Context, tenantID string, id int)) *UserWebAPI_Fetch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(int)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -92,14 +105,65 @@ func (_c *UserWebAPI_Fetch_Call) Return(user1 user.User, err error) *UserWebAPI_
 	return _c
 }
 
-func (_c *UserWebAPI_Fetch_Call) RunAndReturn(run func(n int) (user.User, error)) *UserWebAPI_Fetch_Call {
+func (_c *UserWebAPI_Fetch_Call) RunAndReturn(run func(ctx context.Context, tenantID string, id int) (user.User, error)) *UserWebAPI_Fetch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ping provides a mock function for the type UserWebAPI
+func (_mock *UserWebAPI) Ping(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserWebAPI_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type UserWebAPI_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *UserWebAPI_Expecter) Ping(ctx interface{}) *UserWebAPI_Ping_Call {
+	return &UserWebAPI_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *UserWebAPI_Ping_Call) Run(run func(ctx context.Context)) *UserWebAPI_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *UserWebAPI_Ping_Call) Return(err error) *UserWebAPI_Ping_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserWebAPI_Ping_Call) RunAndReturn(run func(ctx context.Context) error) *UserWebAPI_Ping_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Save provides a mock function for the type UserWebAPI
-func (_mock *UserWebAPI) Save(user1 user.User) (user.User, error) {
-	ret := _mock.Called(user1)
+func (_mock *UserWebAPI) Save(ctx context.Context, tenantID string, u user.User) (user.User, error) {
+	ret := _mock.Called(ctx, tenantID, u)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
@@ -107,16 +171,16 @@ func (_mock *UserWebAPI) Save(user1 user.User) (user.User, error) {
 
 	var r0 user.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(user.User) (user.User, error)); ok {
-		return returnFunc(user1)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, user.User) (user.User, error)); ok {
+		return returnFunc(ctx, tenantID, u)
 	}
-	if returnFunc, ok := ret.Get(0).(func(user.User) user.User); ok {
-		r0 = returnFunc(user1)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, user.User) user.User); ok {
+		r0 = returnFunc(ctx, tenantID, u)
 	} else {
 		r0 = ret.Get(0).(user.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(user.User) error); ok {
-		r1 = returnFunc(user1)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, user.User) error); ok {
+		r1 = returnFunc(ctx, tenantID, u)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -129,30 +193,42 @@ type UserWebAPI_Save_Call struct {
 }
 
 // Save is a helper method to define mock.On call
-//   - user1 user.User
-func (_e *UserWebAPI_Expecter) Save(user1 interface{}) *UserWebAPI_Save_Call {
-	return &UserWebAPI_Save_Call{Call: _e.mock.On("Save", user1)}
+//   - ctx context.Context
+//   - tenantID string
+//   - u user.User
+func (_e *UserWebAPI_Expecter) Save(ctx interface{}, tenantID interface{}, u interface{}) *UserWebAPI_Save_Call {
+	return &UserWebAPI_Save_Call{Call: _e.mock.On("Save", ctx, tenantID, u)}
 }
 
-func (_c *UserWebAPI_Save_Call) Run(run func(user1 user.User)) *UserWebAPI_Save_Call {
+func (_c *UserWebAPI_Save_Call) Run(run func(ctx context.Context, tenantID string, u user.User)) *UserWebAPI_Save_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 user.User
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(user.User)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 user.User
+		if args[2] != nil {
+			arg2 = args[2].(user.User)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *UserWebAPI_Save_Call) Return(user11 user.User, err error) *UserWebAPI_Save_Call {
-	_c.Call.Return(user11, err)
+func (_c *UserWebAPI_Save_Call) Return(user1 user.User, err error) *UserWebAPI_Save_Call {
+	_c.Call.Return(user1, err)
 	return _c
 }
 
-func (_c *UserWebAPI_Save_Call) RunAndReturn(run func(user1 user.User) (user.User, error)) *UserWebAPI_Save_Call {
+func (_c *UserWebAPI_Save_Call) RunAndReturn(run func(ctx context.Context, tenantID string, u user.User) (user.User, error)) *UserWebAPI_Save_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1,6 +1,9 @@
 package persistent
 
 import (
+	"context"
+	"errors"
+
 	"github.com/evrone/go-clean-template/pkg/postgres"
 
 	"go-service-template/internal/domain/user"
@@ -17,12 +20,22 @@ func NewUserRepo(pg *postgres.Postgres) repo.UserRepo {
 	return &userRepo{pg}
 }
 
-// Fetch -.
-func (r *userRepo) Fetch(_ int) (user.User, error) {
+// Fetch returns the user id of tenantID. Queries must filter on the tenant column.
+func (r *userRepo) Fetch(_ context.Context, _ string, _ int) (user.User, error) {
 	return user.User{}, nil
 }
 
-// Save -.
-func (r *userRepo) Save(_ user.User) (user.User, error) {
+// Save stores u for tenantID. Rows must carry the tenant column.
+func (r *userRepo) Save(_ context.Context, _ string, _ user.User) (user.User, error) {
 	return user.User{}, nil
 }
+
+// Ping checks that the database answers.
+func (r *userRepo) Ping(ctx context.Context) error {
+	if r.Postgres == nil || r.Pool == nil {
+		return ErrNoDatabase
+	}
+	return r.Pool.Ping(ctx)
+}
+
+var ErrNoDatabase = errors.New("no database configured")
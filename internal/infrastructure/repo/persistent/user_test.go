@@ -1,6 +1,7 @@
 package persistent
 
 import (
+    "context"
     "testing"
 
     "github.com/evrone/go-clean-template/pkg/postgres"
@@ -16,14 +17,20 @@ func TestNewUserRepo_ReturnsImpl(t *testing.T) {
 
 func TestUserRepo_Save_Defaults(t *testing.T) {
     r := &userRepo{Postgres: nil}
-    _, err := r.Save(domain.User{})
+    _, err := r.Save(context.Background(), "acme", domain.User{})
     assert.NoError(t, err)
 }
 
 func TestUserRepo_Fetch_Defaults(t *testing.T) {
     r := &userRepo{Postgres: nil}
-    _, err := r.Fetch(1)
+    _, err := r.Fetch(context.Background(), "acme", 1)
     assert.NoError(t, err)
 }
 
 
+
+func TestUserRepo_Ping_NoDatabase(t *testing.T) {
+    r := &userRepo{Postgres: nil}
+    err := r.Ping(context.Background())
+    assert.ErrorIs(t, err, ErrNoDatabase)
+}
@@ -1,15 +1,24 @@
 package repo
 
-import "go-service-template/internal/domain/user"
+import (
+	"context"
 
-// UserRepo interface for user repository operations.
+	"go-service-template/internal/domain/user"
+)
+
+// UserRepo interface for user repository operations. Users are stored per tenant: a
+// user is only visible to the tenant it was saved for.
 type UserRepo interface {
-	Save(user.User) (user.User, error)
-	Fetch(int) (user.User, error)
+	Save(ctx context.Context, tenantID string, u user.User) (user.User, error)
+	Fetch(ctx context.Context, tenantID string, id int) (user.User, error)
+	// Ping checks that the database answers, for the readiness probe.
+	Ping(ctx context.Context) error
 }
 
-// UserWebAPI interface for user web API operations.
+// UserWebAPI interface for user web API operations, scoped by tenant like UserRepo.
 type UserWebAPI interface {
-	Save(user.User) (user.User, error)
-	Fetch(int) (user.User, error)
+	Save(ctx context.Context, tenantID string, u user.User) (user.User, error)
+	Fetch(ctx context.Context, tenantID string, id int) (user.User, error)
+	// Ping checks that the upstream answers, for the readiness probe.
+	Ping(ctx context.Context) error
 }
@@ -1,24 +1,116 @@
 package webapi
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
 	"go-service-template/internal/domain/user"
+	"go-service-template/internal/infrastructure/config"
 	"go-service-template/internal/infrastructure/repo"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 // UserWebAPI -.
-type userWebAPI struct{}
+type userWebAPI struct {
+	client  *http.Client
+	baseURL string
+}
 
-// NewUserWebAPI -.
-func NewUserWebAPI() repo.UserWebAPI {
-	return &userWebAPI{}
+// NewUserWebAPI creates the client of the upstream user API. Requests are traced as
+// child spans of the caller's span and carry its trace context to the upstream.
+func NewUserWebAPI(cfg config.UserAPIConfig) repo.UserWebAPI {
+	return &userWebAPI{
+		client: &http.Client{
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+			Timeout:   cfg.Timeout,
+		},
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+	}
 }
 
-// Fetch -.
-func (r *userWebAPI) Fetch(_ int) (user.User, error) {
-	return user.User{}, nil
+// Fetch gets the user from GET {base}/users/{id}. Without a base URL it returns an empty user.
+func (r *userWebAPI) Fetch(ctx context.Context, tenantID string, id int) (user.User, error) {
+	if r.baseURL == "" {
+		return user.User{}, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.baseURL+usersPath+"/"+strconv.Itoa(id), http.NoBody)
+	if err != nil {
+		return user.User{}, fmt.Errorf("fetch user: %w", err)
+	}
+	return r.do(req, tenantID)
 }
 
-// Save -.
-func (r *userWebAPI) Save(_ user.User) (user.User, error) {
-	return user.User{}, nil
+// Save posts the user to POST {base}/users. Without a base URL it returns an empty user.
+func (r *userWebAPI) Save(ctx context.Context, tenantID string, u user.User) (user.User, error) {
+	if r.baseURL == "" {
+		return user.User{}, nil
+	}
+	body, err := json.Marshal(u)
+	if err != nil {
+		return user.User{}, fmt.Errorf("save user: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.baseURL+usersPath, bytes.NewReader(body))
+	if err != nil {
+		return user.User{}, fmt.Errorf("save user: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return r.do(req, tenantID)
 }
+
+// Ping sends HEAD {base}/. Any response below 500 shows the upstream is reachable.
+func (r *userWebAPI) Ping(ctx context.Context) error {
+	if r.baseURL == "" {
+		return ErrNotConfigured
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, r.baseURL+"/", http.NoBody)
+	if err != nil {
+		return fmt.Errorf("ping user API: %w", err)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ping user API: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: HEAD / returned %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+	return nil
+}
+
+// do sends req on behalf of tenantID, which the upstream reads from TenantHeader.
+func (r *userWebAPI) do(req *http.Request, tenantID string) (user.User, error) {
+	if tenantID != "" {
+		req.Header.Set(TenantHeader, tenantID)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return user.User{}, fmt.Errorf("call user API: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return user.User{}, fmt.Errorf("%w: %s %s returned %d", ErrUnexpectedStatus, req.Method, req.URL.Path, resp.StatusCode)
+	}
+	var u user.User
+	if err := json.NewDecoder(resp.Body).Decode(&u); err != nil {
+		return user.User{}, fmt.Errorf("decode user: %w", err)
+	}
+	return u, nil
+}
+
+const (
+	usersPath = "/users"
+	// TenantHeader carries the tenant of the request to the upstream user API.
+	TenantHeader = "X-Tenant-ID"
+)
+
+var (
+	ErrUnexpectedStatus = errors.New("unexpected user API status")
+	ErrNotConfigured    = errors.New("user API base URL not configured")
+)
@@ -1,26 +1,108 @@
 package webapi
 
 import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
     "testing"
+
     domain "go-service-template/internal/domain/user"
+    "go-service-template/internal/infrastructure/config"
+
     "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/propagation"
+    sdktrace "go.opentelemetry.io/otel/sdk/trace"
+    "go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestNewUserWebAPI_ReturnsImpl(t *testing.T) {
-    w := NewUserWebAPI()
+    w := NewUserWebAPI(config.UserAPIConfig{})
     assert.NotNil(t, w)
 }
 
 func TestUserWebAPI_Save_Defaults(t *testing.T) {
     w := &userWebAPI{}
-    _, err := w.Save(domain.User{})
+    _, err := w.Save(context.Background(), "", domain.User{})
     assert.NoError(t, err)
 }
 
 func TestUserWebAPI_Fetch_Defaults(t *testing.T) {
     w := &userWebAPI{}
-    _, err := w.Fetch(1)
+    _, err := w.Fetch(context.Background(), "", 1)
     assert.NoError(t, err)
 }
 
+func TestUserWebAPI_Fetch_TracesOutboundCall(t *testing.T) {
+    previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+    t.Cleanup(func() {
+        otel.SetTracerProvider(previousProvider)
+        otel.SetTextMapPropagator(previousPropagator)
+    })
+    recorder := tracetest.NewSpanRecorder()
+    otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+    otel.SetTextMapPropagator(propagation.TraceContext{})
+    var traceparent string
+    upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        traceparent = r.Header.Get("traceparent")
+        assert.Equal(t, "/users/7", r.URL.Path)
+        _ = json.NewEncoder(w).Encode(domain.User{ID: 7, Name: "Alice"})
+    }))
+    defer upstream.Close()
+    w := NewUserWebAPI(config.UserAPIConfig{BaseURL: upstream.URL + "/"})
+
+    ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+    u, err := w.Fetch(ctx, "", 7)
+    parent.End()
+
+    require.NoError(t, err)
+    assert.Equal(t, "Alice", u.Name)
+    spans := recorder.Ended()
+    require.Len(t, spans, 2)
+    assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+    assert.Contains(t, traceparent, parent.SpanContext().TraceID().String())
+}
+
+func TestUserWebAPI_Fetch_SendsTenant(t *testing.T) {
+    var tenant string
+    upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        tenant = r.Header.Get(TenantHeader)
+        _ = json.NewEncoder(w).Encode(domain.User{ID: 7})
+    }))
+    defer upstream.Close()
+    w := NewUserWebAPI(config.UserAPIConfig{BaseURL: upstream.URL})
 
+    _, err := w.Fetch(context.Background(), "acme", 7)
+
+    require.NoError(t, err)
+    assert.Equal(t, "acme", tenant)
+}
+
+func TestUserWebAPI_Save_UnexpectedStatus(t *testing.T) {
+    upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        assert.Equal(t, http.MethodPost, r.Method)
+        w.WriteHeader(http.StatusBadGateway)
+    }))
+    defer upstream.Close()
+    w := NewUserWebAPI(config.UserAPIConfig{BaseURL: upstream.URL})
+
+    _, err := w.Save(context.Background(), "", domain.User{ID: 7})
+
+    assert.ErrorIs(t, err, ErrUnexpectedStatus)
+}
+
+func TestUserWebAPI_Ping(t *testing.T) {
+    status := http.StatusNotFound
+    upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(status)
+    }))
+    defer upstream.Close()
+    w := NewUserWebAPI(config.UserAPIConfig{BaseURL: upstream.URL})
+
+    assert.NoError(t, w.Ping(context.Background()))
+    status = http.StatusServiceUnavailable
+    assert.ErrorIs(t, w.Ping(context.Background()), ErrUnexpectedStatus)
+    assert.ErrorIs(t, NewUserWebAPI(config.UserAPIConfig{}).Ping(context.Background()), ErrNotConfigured)
+}
@@ -0,0 +1,25 @@
+package tenant
+
+import "context"
+
+// WithTenant returns a copy of ctx carrying the tenant id.
+func WithTenant(ctx context.Context, id string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// FromContext returns the tenant attached to ctx by Middleware, or "" when there is none.
+// Storage treats "" as the default tenant.
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(tenantKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
+
+type tenantKey struct{}
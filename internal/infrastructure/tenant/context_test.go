@@ -0,0 +1,19 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext_WithTenant_ReturnsTenant(t *testing.T) {
+	ctx := WithTenant(context.Background(), "acme")
+
+	assert.Equal(t, "acme", FromContext(ctx))
+}
+
+func TestFromContext_NoTenant_ReturnsEmpty(t *testing.T) {
+	assert.Empty(t, FromContext(context.Background()))
+	assert.Empty(t, FromContext(nil))
+}
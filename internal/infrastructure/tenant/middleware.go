@@ -0,0 +1,99 @@
+package tenant
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"go-service-template/internal/infrastructure/auth"
+	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware resolves the tenant of the request and attaches it to the request context,
+// the log fields and the request span. It must run after logger.LoggingMiddleware and, when
+// the API is authenticated, after auth.Middleware. The tenancy settings are read on every
+// request so configuration reloads apply immediately.
+//
+// Requests without a valid tenant are rejected with 400, and requests for a tenant the
+// strict mode does not know, or that their credentials are not bound to, with 403.
+func Middleware(cfg config.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenancy := cfg.GetTenancyConfig()
+		logCtx := logger.GetLogContext(c)
+		id, status, message := resolve(&tenancy, c.Request, auth.FromContext(logCtx))
+		if status != http.StatusOK {
+			logger.Warn(logCtx, "Rejected request tenant",
+				logger.String(logger.FieldTenant, id),
+				logger.Int(logger.FieldStatusCode, status),
+			)
+			c.AbortWithStatusJSON(status, gin.H{"error": message})
+			return
+		}
+
+		ctx := logger.WithFields(WithTenant(logCtx, id), logger.String(logger.FieldTenant, id))
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String(AttributeTenantID, id))
+		logger.SetLogContext(ctx, c)
+		c.Next()
+	}
+}
+
+// resolve returns the tenant of req and the status rejecting it, http.StatusOK when it is
+// accepted. An authenticated caller is bound to the tenant of its credentials, the default
+// tenant when they name none: it may leave the tenant out of req but never name another.
+func resolve(tenancy *config.TenancyConfig, req *http.Request, claims *auth.Claims) (id string, status int, message string) {
+	id = Resolve(tenancy, req)
+	if claims != nil {
+		bound := claims.Tenant
+		if bound == "" {
+			bound = tenancy.Default
+		}
+		if requested := Requested(tenancy, req); requested != "" && requested != bound {
+			return requested, http.StatusForbidden, "tenant not allowed for these credentials"
+		}
+		id = bound
+	}
+	switch {
+	case id == "":
+		return id, http.StatusBadRequest, "missing tenant"
+	case !config.ValidTenantID(id):
+		return id, http.StatusBadRequest, "invalid tenant"
+	case !tenancy.Known(id):
+		return id, http.StatusForbidden, "unknown tenant"
+	}
+	return id, http.StatusOK, ""
+}
+
+// Resolve returns the tenant requested by req, and the default tenant when it requests
+// none. The result is not validated.
+func Resolve(tenancy *config.TenancyConfig, req *http.Request) string {
+	if id := Requested(tenancy, req); id != "" {
+		return id
+	}
+	return tenancy.Default
+}
+
+// Requested returns the tenant named by the tenant header of req, then the tenant mapped
+// to its host, and "" otherwise.
+func Requested(tenancy *config.TenancyConfig, req *http.Request) string {
+	if id := strings.TrimSpace(req.Header.Get(tenancy.Header)); id != "" {
+		return id
+	}
+	host := req.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	for mapped, id := range tenancy.Hosts {
+		if strings.EqualFold(mapped, host) {
+			return id
+		}
+	}
+	return ""
+}
+
+// AttributeTenantID is the span attribute holding the tenant of the request.
+const AttributeTenantID = "tenant.id"
@@ -0,0 +1,137 @@
+package tenant
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-service-template/internal/infrastructure/auth"
+	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTenantRouter(cfg *config.Config, seen *string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(logger.LoggingMiddleware(), Middleware(cfg))
+	r.GET("/api", func(c *gin.Context) {
+		*seen = FromContext(logger.GetLogContext(c))
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func serve(r *gin.Engine, host, tenantHeader string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api", nil)
+	req.Host = host
+	if tenantHeader != "" {
+		req.Header.Set(config.DefaultTenantHeader, tenantHeader)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_ResolvesTenant(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Tenancy.Hosts = map[string]string{"acme.example.com": "acme"}
+	tests := []struct {
+		name, host, header, want string
+	}{
+		{name: "header", host: "acme.example.com", header: "globex", want: "globex"},
+		{name: "host", host: "ACME.example.com:8080", want: "acme"},
+		{name: "default", host: "other.example.com", want: config.DefaultTenant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			w := serve(newTenantRouter(cfg, &seen), tt.host, tt.header)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.want, seen)
+		})
+	}
+}
+
+func TestMiddleware_RejectsRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		setup  func(*config.Config)
+		status int
+	}{
+		{name: "missing", setup: func(c *config.Config) { c.Tenancy.Default = "" }, status: http.StatusBadRequest},
+		{name: "invalid", header: "acme corp", setup: func(*config.Config) {}, status: http.StatusBadRequest},
+		{name: "unknown in strict mode", header: "globex", setup: func(c *config.Config) {
+			c.Tenancy.Strict = true
+			c.Tenancy.Tenants = map[string]config.TenantConfig{"acme": {}}
+		}, status: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewConfig()
+			tt.setup(cfg)
+			seen := "untouched"
+
+			w := serve(newTenantRouter(cfg, &seen), "example.com", tt.header)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, "untouched", seen, "the handler must not run")
+		})
+	}
+}
+
+func TestMiddleware_Strict_AcceptsKnownAndDefaultTenants(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Tenancy.Strict = true
+	cfg.Tenancy.Tenants = map[string]config.TenantConfig{"acme": {}}
+	var seen string
+	r := newTenantRouter(cfg, &seen)
+
+	assert.Equal(t, http.StatusOK, serve(r, "example.com", "acme").Code)
+	assert.Equal(t, http.StatusOK, serve(r, "example.com", "").Code)
+	assert.Equal(t, config.DefaultTenant, seen)
+}
+
+func newAuthenticatedTenantRouter(cfg *config.Config, claims *auth.Claims, seen *string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(logger.LoggingMiddleware(), func(c *gin.Context) {
+		logger.SetLogContext(auth.WithClaims(logger.GetLogContext(c), claims), c)
+	}, Middleware(cfg))
+	r.GET("/api", func(c *gin.Context) {
+		*seen = FromContext(logger.GetLogContext(c))
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func TestMiddleware_Authenticated_BindsTenantToCredentials(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.Tenancy.Hosts = map[string]string{"globex.example.com": "globex"}
+	tests := []struct {
+		name, tenant, host, header string
+		status                     int
+		want                       string
+	}{
+		{name: "own tenant omitted", tenant: "acme", host: "example.com", status: http.StatusOK, want: "acme"},
+		{name: "own tenant named", tenant: "acme", host: "example.com", header: "acme", status: http.StatusOK, want: "acme"},
+		{name: "other tenant header", tenant: "acme", host: "example.com", header: "globex", status: http.StatusForbidden},
+		{name: "other tenant host", tenant: "acme", host: "globex.example.com", status: http.StatusForbidden},
+		{name: "unbound uses default", host: "example.com", status: http.StatusOK, want: config.DefaultTenant},
+		{name: "unbound names another", host: "example.com", header: "acme", status: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			w := serve(newAuthenticatedTenantRouter(cfg, &auth.Claims{Subject: "user-1", Tenant: tt.tenant}, &seen), tt.host, tt.header)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, tt.want, seen)
+		})
+	}
+}
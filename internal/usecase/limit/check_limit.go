@@ -1,17 +1,122 @@
 package limit
 
-import "go-service-template/internal/api/dto"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
-func (s *UseCase) CheckLimit(req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error) {
+	"go-service-template/internal/api/dto"
+	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/provider/redis"
+	"go-service-template/internal/infrastructure/tenant"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CheckLimit consumes one request from the user's current window and returns what is left.
+// Policies and counters are those of the tenant carried by ctx. Without Redis nothing can
+// be counted, so no limit is reported as available.
+func (s *UseCase) CheckLimit(ctx context.Context, req *dto.CheckLimitRequest) (_ dto.CheckLimitResponse, err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "limit.CheckLimit")
+	defer func() { endSpan(span, err) }()
 	if req == nil {
 		return dto.CheckLimitResponse{}, nil
 	}
-	return dto.CheckLimitResponse{UserID: req.UserID, LimitAvailable: 0}, nil
+	tenantID := tenant.FromContext(ctx)
+	name, policy, err := s.policy(span, tenantID, req)
+	if err != nil {
+		return dto.CheckLimitResponse{}, err
+	}
+	if req.Policy != "" {
+		s.instrumentation.PolicyOverridden(ctx, name)
+	}
+	if !s.redisProvider.Ready() {
+		return dto.CheckLimitResponse{UserID: req.UserID, LimitAvailable: 0}, nil
+	}
+
+	start := time.Now()
+	used, err := fixedWindowScript.Run(ctx, s.redisProvider.GetClient(),
+		[]string{s.limitKey(tenantID, name, req.UserID)}, policy.Window.Milliseconds()).Int()
+	s.instrumentation.ScriptExecuted(ctx, fixedWindowScriptName, time.Since(start))
+	if err != nil {
+		return dto.CheckLimitResponse{}, fmt.Errorf("check limit: %w", err)
+	}
+	s.instrumentation.LimitChecked(ctx, name, used <= policy.Limit)
+	return dto.CheckLimitResponse{UserID: req.UserID, LimitAvailable: max(policy.Limit-used, 0)}, nil
 }
 
-func (s *UseCase) ResetLimit(req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error) {
+// ResetLimit clears the user's current window and returns the full policy limit.
+func (s *UseCase) ResetLimit(ctx context.Context, req *dto.CheckLimitRequest) (_ dto.CheckLimitResponse, err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "limit.ResetLimit")
+	defer func() { endSpan(span, err) }()
 	if req == nil {
 		return dto.CheckLimitResponse{}, nil
 	}
-	return dto.CheckLimitResponse{UserID: req.UserID, LimitAvailable: 0}, nil
+	tenantID := tenant.FromContext(ctx)
+	name, policy, err := s.policy(span, tenantID, req)
+	if err != nil {
+		return dto.CheckLimitResponse{}, err
+	}
+	if !s.redisProvider.Ready() {
+		return dto.CheckLimitResponse{UserID: req.UserID, LimitAvailable: 0}, nil
+	}
+
+	if err := s.redisProvider.GetClient().Del(ctx, s.limitKey(tenantID, name, req.UserID)).Err(); err != nil {
+		return dto.CheckLimitResponse{}, fmt.Errorf("reset limit: %w", err)
+	}
+	s.instrumentation.LimitReset(ctx, name)
+	return dto.CheckLimitResponse{UserID: req.UserID, LimitAvailable: policy.Limit}, nil
 }
+
+// policy resolves the request's policy for tenantID and records it and the user on span.
+func (s *UseCase) policy(span trace.Span, tenantID string, req *dto.CheckLimitRequest) (string, config.LimitPolicy, error) {
+	name, policy, ok := s.config.GetTenantLimitConfig(tenantID).Policy(req.Policy)
+	span.SetAttributes(attribute.Int(AttributeUserID, req.UserID), attribute.String(AttributePolicy, name))
+	if !ok {
+		return name, policy, fmt.Errorf("%w: %q", ErrUnknownPolicy, name)
+	}
+	return name, policy, nil
+}
+
+// limitKey wraps the user ID in a hash tag, so in cluster mode every counter of a user
+// lives in the same slot and a script may touch several of them.
+func (s *UseCase) limitKey(tenantID, policy string, userID int) string {
+	return s.redisProvider.Keys().Key(tenantID, limitKeyPart, redis.HashTag(strconv.Itoa(userID)), policy)
+}
+
+// endSpan marks span as failed when err is set, then ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+var ErrUnknownPolicy = errors.New("unknown limit policy")
+
+const (
+	fixedWindowScriptName = "fixed_window"
+	limitKeyPart          = "limit"
+	tracerName            = "go-service-template/internal/usecase/limit"
+	AttributeUserID       = "user.id"
+	AttributePolicy       = "limit.policy"
+)
+
+// fixedWindowScript increments the counter and starts the window on the first hit atomically.
+// Like every script run in cluster mode, it only touches the keys it is passed.
+//
+//nolint:gochecknoglobals // Scripts are compiled once and their SHA reused by EVALSHA.
+var fixedWindowScript = goredis.NewScript(`
+local current = redis.call('INCR', KEYS[1])
+if current == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return current
+`)
@@ -0,0 +1,30 @@
+package limit
+
+import (
+	"context"
+	"time"
+)
+
+// Instrumentation receives the business events of the limit use case. Implementations
+// must be safe for concurrent use.
+type Instrumentation interface {
+	// LimitChecked records a limit check under policy and whether it was within the limit.
+	LimitChecked(ctx context.Context, policy string, allowed bool)
+	// LimitReset records a reset of a user's window under policy.
+	LimitReset(ctx context.Context, policy string)
+	// PolicyOverridden records a request naming its policy instead of using the default.
+	PolicyOverridden(ctx context.Context, policy string)
+	// ScriptExecuted records how long a Redis script took, whether or not it failed.
+	ScriptExecuted(ctx context.Context, script string, duration time.Duration)
+}
+
+// NopInstrumentation discards every event.
+type NopInstrumentation struct{}
+
+func (NopInstrumentation) LimitChecked(context.Context, string, bool) {}
+
+func (NopInstrumentation) LimitReset(context.Context, string) {}
+
+func (NopInstrumentation) PolicyOverridden(context.Context, string) {}
+
+func (NopInstrumentation) ScriptExecuted(context.Context, string, time.Duration) {}
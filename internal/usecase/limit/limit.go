@@ -1,21 +1,35 @@
 package limit
 
 import (
+	"context"
+
 	"go-service-template/internal/api/dto"
+	"go-service-template/internal/infrastructure/config"
 	"go-service-template/internal/infrastructure/provider/redis"
 )
 
 type UseCase struct {
-	redisProvider *redis.Provider
+	redisProvider   *redis.Provider
+	config          config.Provider
+	instrumentation Instrumentation
 }
 
-func NewLimitUseCase(redisProvider *redis.Provider) *UseCase {
+// NewLimitUseCase creates the limit use case. Policies are looked up from cfg on every
+// call, so reloading the configuration changes limits without a restart. A nil
+// instrumentation discards the business events.
+func NewLimitUseCase(redisProvider *redis.Provider, cfg config.Provider, instrumentation Instrumentation) *UseCase {
+	if instrumentation == nil {
+		instrumentation = NopInstrumentation{}
+	}
 	return &UseCase{
-		redisProvider: redisProvider,
+		redisProvider:   redisProvider,
+		config:          cfg,
+		instrumentation: instrumentation,
 	}
 }
 
+// ILimitUseCase checks the limits of the tenant carried by ctx, see tenant.WithTenant.
 type ILimitUseCase interface {
-	CheckLimit(_ *dto.CheckLimitRequest) (dto.CheckLimitResponse, error)
-	ResetLimit(_ *dto.CheckLimitRequest) (dto.CheckLimitResponse, error)
+	CheckLimit(ctx context.Context, req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error)
+	ResetLimit(ctx context.Context, req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error)
 }
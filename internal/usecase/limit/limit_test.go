@@ -1,16 +1,30 @@
 package limit
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"go-service-template/internal/api/dto"
+	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/provider/redis"
+	"go-service-template/internal/infrastructure/tenant"
+	"go-service-template/internal/usecase/limit/mocks"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 
 func TestNewLimitUseCase_ValidInput_ReturnsLimitUseCase(t *testing.T) {
-	useCase := NewLimitUseCase(nil)
+	useCase := NewLimitUseCase(nil, config.NewConfig(), nil)
 
 	assert.NotNil(t, useCase)
 	assert.IsType(t, &UseCase{}, useCase)
@@ -18,7 +32,7 @@ func TestNewLimitUseCase_ValidInput_ReturnsLimitUseCase(t *testing.T) {
 }
 
 func TestNewLimitUseCase_NilInput_ReturnsLimitUseCase(t *testing.T) {
-	useCase := NewLimitUseCase(nil)
+	useCase := NewLimitUseCase(nil, config.NewConfig(), nil)
 
 	assert.NotNil(t, useCase)
 	assert.IsType(t, &UseCase{}, useCase)
@@ -26,13 +40,13 @@ func TestNewLimitUseCase_NilInput_ReturnsLimitUseCase(t *testing.T) {
 }
 
 func TestUseCase_CheckLimit_ValidRequest_ReturnsResponse(t *testing.T) {
-	useCase := NewLimitUseCase(nil)
+	useCase := NewLimitUseCase(nil, config.NewConfig(), nil)
 
 	request := &dto.CheckLimitRequest{
 		UserID: 123,
 	}
 
-	response, err := useCase.CheckLimit(request)
+	response, err := useCase.CheckLimit(context.Background(), request)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -40,9 +54,9 @@ func TestUseCase_CheckLimit_ValidRequest_ReturnsResponse(t *testing.T) {
 }
 
 func TestUseCase_CheckLimit_NilRequest_ReturnsResponse(t *testing.T) {
-	useCase := NewLimitUseCase(nil)
+	useCase := NewLimitUseCase(nil, config.NewConfig(), nil)
 
-	response, err := useCase.CheckLimit(nil)
+	response, err := useCase.CheckLimit(context.Background(), nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -50,10 +64,10 @@ func TestUseCase_CheckLimit_NilRequest_ReturnsResponse(t *testing.T) {
 }
 
 func TestUseCase_CheckLimit_EmptyRequest_ReturnsResponse(t *testing.T) {
-	useCase := NewLimitUseCase(nil)
+	useCase := NewLimitUseCase(nil, config.NewConfig(), nil)
 
 	request := &dto.CheckLimitRequest{}
-	response, err := useCase.CheckLimit(request)
+	response, err := useCase.CheckLimit(context.Background(), request)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -105,9 +119,9 @@ func TestUseCase_CheckLimit_EdgeCaseUserIDs_ReturnsResponse(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			useCase := NewLimitUseCase(nil)
+			useCase := NewLimitUseCase(nil, config.NewConfig(), nil)
 
-			response, err := useCase.CheckLimit(tt.request)
+			response, err := useCase.CheckLimit(context.Background(), tt.request)
 
 			assert.NoError(t, err)
 			assert.NotNil(t, response)
@@ -117,12 +131,12 @@ func TestUseCase_CheckLimit_EdgeCaseUserIDs_ReturnsResponse(t *testing.T) {
 }
 
 func TestUseCase_CheckLimit_ResponseStructure_ReturnsCorrectType(t *testing.T) {
-	useCase := NewLimitUseCase(nil)
+	useCase := NewLimitUseCase(nil, config.NewConfig(), nil)
 	request := &dto.CheckLimitRequest{
 		UserID: 123,
 	}
 
-	response, err := useCase.CheckLimit(request)
+	response, err := useCase.CheckLimit(context.Background(), request)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
@@ -131,20 +145,254 @@ func TestUseCase_CheckLimit_ResponseStructure_ReturnsCorrectType(t *testing.T) {
 }
 
 func TestUseCase_CheckLimit_InterfaceCompliance(t *testing.T) {
-	useCase := NewLimitUseCase(nil)
+	useCase := NewLimitUseCase(nil, config.NewConfig(), nil)
 	var _ ILimitUseCase = useCase
 	request := &dto.CheckLimitRequest{UserID: 123}
 	
-	_, err := useCase.CheckLimit(request)
+	_, err := useCase.CheckLimit(context.Background(), request)
 
 	assert.NoError(t, err)
 }
 
 func TestUseCase_CheckLimit_ErrorHandling(t *testing.T) {
-	useCase := NewLimitUseCase(nil)
+	useCase := NewLimitUseCase(nil, config.NewConfig(), nil)
 	request := &dto.CheckLimitRequest{UserID: 123}
 
-	_, err := useCase.CheckLimit(request)
+	_, err := useCase.CheckLimit(context.Background(), request)
 	
 	assert.NoError(t, err)
-}
\ No newline at end of file
+}
+
+func newRedisLimitUseCase(t *testing.T) (*UseCase, *config.Config, *miniredis.Miniredis) {
+	t.Helper()
+	return newInstrumentedLimitUseCase(t, nil)
+}
+
+func newInstrumentedLimitUseCase(t *testing.T, instrumentation Instrumentation) (*UseCase, *config.Config, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	t.Setenv(config.EnvRedisHost, mr.Addr())
+	cfg := config.NewConfig()
+	cfg.Limit.Policies["tight"] = config.LimitPolicy{Limit: 2, Window: time.Minute}
+	provider, err := redis.NewProvider(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = provider.Close() })
+	require.NoError(t, provider.Ping(context.Background()), "Redis is skipped until it answers a ping")
+	return NewLimitUseCase(provider, cfg, instrumentation), cfg, mr
+}
+
+func TestUseCase_CheckLimit_WithRedis_ConsumesWindow(t *testing.T) {
+	useCase, _, _ := newRedisLimitUseCase(t)
+	req := &dto.CheckLimitRequest{UserID: 7, Policy: "tight"}
+
+	first, err := useCase.CheckLimit(context.Background(), req)
+	require.NoError(t, err)
+	second, _ := useCase.CheckLimit(context.Background(), req)
+	third, _ := useCase.CheckLimit(context.Background(), req)
+
+	assert.Equal(t, 1, first.LimitAvailable)
+	assert.Equal(t, 0, second.LimitAvailable)
+	assert.Equal(t, 0, third.LimitAvailable)
+}
+
+func TestUseCase_CheckLimit_WithRedis_KeyNamespacedAndHashTaggedByUser(t *testing.T) {
+	useCase, cfg, mr := newRedisLimitUseCase(t)
+
+	_, err := useCase.CheckLimit(context.Background(), &dto.CheckLimitRequest{UserID: 7, Policy: "tight"})
+
+	require.NoError(t, err)
+	prefix := cfg.GetAppName() + ":" + cfg.GetEnv() + ":v1:default:"
+	assert.Equal(t, []string{prefix + "limit:{7}:tight"}, mr.Keys())
+}
+
+func TestUseCase_CheckLimit_Tenant_UsesTenantPolicyAndKeys(t *testing.T) {
+	useCase, cfg, mr := newRedisLimitUseCase(t)
+	cfg.Tenancy.Tenants = map[string]config.TenantConfig{
+		"acme": {Limit: config.LimitConfig{Policies: map[string]config.LimitPolicy{"tight": {Limit: 5, Window: time.Minute}}}},
+	}
+	req := &dto.CheckLimitRequest{UserID: 7, Policy: "tight"}
+
+	acme, err := useCase.CheckLimit(tenant.WithTenant(context.Background(), "acme"), req)
+	require.NoError(t, err)
+	other, err := useCase.CheckLimit(tenant.WithTenant(context.Background(), "globex"), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, acme.LimitAvailable)
+	assert.Equal(t, 1, other.LimitAvailable)
+	prefix := cfg.GetAppName() + ":" + cfg.GetEnv() + ":v1:"
+	assert.ElementsMatch(t, []string{prefix + "acme:limit:{7}:tight", prefix + "globex:limit:{7}:tight"}, mr.Keys())
+}
+
+func TestUseCase_CheckLimit_ClusterMode_ConsumesWindow(t *testing.T) {
+	mr := miniredis.RunT(t)
+	t.Setenv(config.EnvRedisMode, config.RedisModeCluster)
+	t.Setenv(config.EnvRedisAddrs, mr.Addr())
+	cfg := config.NewConfig()
+	cfg.Limit.Policies["tight"] = config.LimitPolicy{Limit: 2, Window: time.Minute}
+	provider, err := redis.NewProvider(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = provider.Close() })
+	require.NoError(t, provider.Ping(context.Background()))
+	useCase := NewLimitUseCase(provider, cfg, nil)
+	req := &dto.CheckLimitRequest{UserID: 7, Policy: "tight"}
+
+	first, err := useCase.CheckLimit(context.Background(), req)
+	require.NoError(t, err)
+	second, err := useCase.CheckLimit(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, first.LimitAvailable)
+	assert.Equal(t, 0, second.LimitAvailable)
+}
+
+func TestUseCase_CheckLimit_WithRedis_WindowExpires(t *testing.T) {
+	useCase, _, mr := newRedisLimitUseCase(t)
+	req := &dto.CheckLimitRequest{UserID: 7, Policy: "tight"}
+	_, _ = useCase.CheckLimit(context.Background(), req)
+	_, _ = useCase.CheckLimit(context.Background(), req)
+
+	mr.FastForward(time.Minute)
+	response, err := useCase.CheckLimit(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.LimitAvailable)
+}
+
+func TestUseCase_CheckLimit_PolicyChangeAppliesImmediately(t *testing.T) {
+	useCase, cfg, _ := newRedisLimitUseCase(t)
+	req := &dto.CheckLimitRequest{UserID: 7, Policy: "tight"}
+	_, _ = useCase.CheckLimit(context.Background(), req)
+
+	cfg.Limit.Policies["tight"] = config.LimitPolicy{Limit: 10, Window: time.Minute}
+	response, err := useCase.CheckLimit(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.Equal(t, 8, response.LimitAvailable)
+}
+
+func TestUseCase_CheckLimit_UnknownPolicy_ReturnsError(t *testing.T) {
+	useCase := NewLimitUseCase(nil, config.NewConfig(), nil)
+
+	_, err := useCase.CheckLimit(context.Background(), &dto.CheckLimitRequest{UserID: 1, Policy: "missing"})
+
+	assert.ErrorIs(t, err, ErrUnknownPolicy)
+}
+
+func TestUseCase_ResetLimit_WithRedis_RestoresFullLimit(t *testing.T) {
+	useCase, _, _ := newRedisLimitUseCase(t)
+	req := &dto.CheckLimitRequest{UserID: 7, Policy: "tight"}
+	_, _ = useCase.CheckLimit(context.Background(), req)
+
+	reset, err := useCase.ResetLimit(context.Background(), req)
+	require.NoError(t, err)
+	next, _ := useCase.CheckLimit(context.Background(), req)
+
+	assert.Equal(t, 2, reset.LimitAvailable)
+	assert.Equal(t, 1, next.LimitAvailable)
+}
+
+// Until Redis answers a ping, the use case serves as without Redis rather than sending
+// commands that would fail, or block, while the client reconnects.
+func TestUseCase_RedisNotReady_ServesWithoutLimit(t *testing.T) {
+	mr := miniredis.RunT(t)
+	t.Setenv(config.EnvRedisHost, mr.Addr())
+	cfg := config.NewConfig()
+	cfg.Limit.Policies["tight"] = config.LimitPolicy{Limit: 2, Window: time.Minute}
+	provider, err := redis.NewProvider(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = provider.Close() })
+	useCase := NewLimitUseCase(provider, cfg, nil)
+	req := &dto.CheckLimitRequest{UserID: 7, Policy: "tight"}
+
+	checked, err := useCase.CheckLimit(context.Background(), req)
+	require.NoError(t, err)
+	reset, err := useCase.ResetLimit(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, dto.CheckLimitResponse{UserID: 7, LimitAvailable: 0}, checked)
+	assert.Equal(t, dto.CheckLimitResponse{UserID: 7, LimitAvailable: 0}, reset)
+	assert.Empty(t, mr.Keys(), "no command reaches Redis before it is ready")
+
+	require.NoError(t, provider.Ping(context.Background()))
+	ready, err := useCase.CheckLimit(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ready.LimitAvailable)
+}
+
+func TestUseCase_CheckLimit_RecordsBusinessEvents(t *testing.T) {
+	instrumentation := mocks.NewInstrumentation(t)
+	instrumentation.EXPECT().PolicyOverridden(mock.Anything, "tight").Return().Times(3)
+	instrumentation.EXPECT().ScriptExecuted(mock.Anything, fixedWindowScriptName, mock.AnythingOfType("time.Duration")).Return().Times(3)
+	instrumentation.EXPECT().LimitChecked(mock.Anything, "tight", true).Return().Twice()
+	instrumentation.EXPECT().LimitChecked(mock.Anything, "tight", false).Return().Once()
+	instrumentation.EXPECT().LimitReset(mock.Anything, "tight").Return().Once()
+	useCase, _, _ := newInstrumentedLimitUseCase(t, instrumentation)
+	req := &dto.CheckLimitRequest{UserID: 7, Policy: "tight"}
+
+	for range 3 {
+		_, err := useCase.CheckLimit(context.Background(), req)
+		require.NoError(t, err)
+	}
+	_, err := useCase.ResetLimit(context.Background(), req)
+
+	require.NoError(t, err)
+}
+
+func TestUseCase_CheckLimit_DefaultPolicy_NotAnOverride(t *testing.T) {
+	instrumentation := mocks.NewInstrumentation(t)
+	useCase := NewLimitUseCase(nil, config.NewConfig(), instrumentation)
+
+	_, err := useCase.CheckLimit(context.Background(), &dto.CheckLimitRequest{UserID: 7})
+
+	require.NoError(t, err)
+	instrumentation.AssertNotCalled(t, "PolicyOverridden", mock.Anything, mock.Anything)
+}
+
+// recordSpans installs a tracer provider recording the spans of the test, and restores
+// the previous global provider afterwards.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	return recorder
+}
+
+func TestUseCase_CheckLimit_TracesUseCaseAndRedisCommands(t *testing.T) {
+	recorder := recordSpans(t)
+	useCase, _, _ := newRedisLimitUseCase(t)
+
+	_, err := useCase.CheckLimit(context.Background(), &dto.CheckLimitRequest{UserID: 7, Policy: "tight"})
+	require.NoError(t, err)
+
+	var checkSpan sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "limit.CheckLimit" {
+			checkSpan = span
+		}
+	}
+	require.NotNil(t, checkSpan)
+	assert.Contains(t, checkSpan.Attributes(), attribute.Int(AttributeUserID, 7))
+	assert.Contains(t, checkSpan.Attributes(), attribute.String(AttributePolicy, "tight"))
+	var children []string
+	for _, span := range recorder.Ended() {
+		if span.Parent().SpanID() == checkSpan.SpanContext().SpanID() {
+			children = append(children, span.Name())
+		}
+	}
+	assert.NotEmpty(t, children, "Redis commands should be children of the use case span")
+}
+
+func TestUseCase_CheckLimit_UnknownPolicy_MarksSpanFailed(t *testing.T) {
+	recorder := recordSpans(t)
+	useCase := NewLimitUseCase(nil, config.NewConfig(), nil)
+
+	_, err := useCase.CheckLimit(context.Background(), &dto.CheckLimitRequest{UserID: 1, Policy: "missing"})
+
+	require.ErrorIs(t, err, ErrUnknownPolicy)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Len(t, spans[0].Events(), 1)
+}
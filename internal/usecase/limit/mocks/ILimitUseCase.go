@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	"context"
 	"go-service-template/internal/api/dto"
 
 	mock "github.com/stretchr/testify/mock"
@@ -38,8 +39,8 @@ func (_m *ILimitUseCase) EXPECT() *ILimitUseCase_Expecter {
 }
 
 // CheckLimit provides a mock function for the type ILimitUseCase
-func (_mock *ILimitUseCase) CheckLimit(checkLimitRequest *dto.CheckLimitRequest) (dto.CheckLimitResponse, error) {
-	ret := _mock.Called(checkLimitRequest)
+func (_mock *ILimitUseCase) CheckLimit(ctx context.Context, req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CheckLimit")
@@ -47,16 +48,16 @@ func (_mock *ILimitUseCase) CheckLimit(checkLimitRequest *dto.CheckLimitRequest)
 
 	var r0 dto.CheckLimitResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*dto.CheckLimitRequest) (dto.CheckLimitResponse, error)); ok {
-		return returnFunc(checkLimitRequest)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.CheckLimitRequest) (dto.CheckLimitResponse, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*dto.CheckLimitRequest) dto.CheckLimitResponse); ok {
-		r0 = returnFunc(checkLimitRequest)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.CheckLimitRequest) dto.CheckLimitResponse); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		r0 = ret.Get(0).(dto.CheckLimitResponse)
 	}
-	if returnFunc, ok := ret.Get(1).(func(*dto.CheckLimitRequest) error); ok {
-		r1 = returnFunc(checkLimitRequest)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dto.CheckLimitRequest) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -69,19 +70,25 @@ type ILimitUseCase_CheckLimit_Call struct {
 }
 
 // CheckLimit is a helper method to define mock.On call
-//   - checkLimitRequest *dto.CheckLimitRequest
-func (_e *ILimitUseCase_Expecter) CheckLimit(checkLimitRequest interface{}) *ILimitUseCase_CheckLimit_Call {
-	return &ILimitUseCase_CheckLimit_Call{Call: _e.mock.On("CheckLimit", checkLimitRequest)}
+//   - ctx context.Context
+//   - req *dto.CheckLimitRequest
+func (_e *ILimitUseCase_Expecter) CheckLimit(ctx interface{}, req interface{}) *ILimitUseCase_CheckLimit_Call {
+	return &ILimitUseCase_CheckLimit_Call{Call: _e.mock.On("CheckLimit", ctx, req)}
 }
 
-func (_c *ILimitUseCase_CheckLimit_Call) Run(run func(checkLimitRequest *dto.CheckLimitRequest)) *ILimitUseCase_CheckLimit_Call {
+func (_c *ILimitUseCase_CheckLimit_Call) Run(run func(ctx context.Context, req *dto.CheckLimitRequest)) *ILimitUseCase_CheckLimit_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *dto.CheckLimitRequest
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*dto.CheckLimitRequest)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dto.CheckLimitRequest
+		if args[1] != nil {
+			arg1 = args[1].(*dto.CheckLimitRequest)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -92,14 +99,14 @@ func (_c *ILimitUseCase_CheckLimit_Call) Return(checkLimitResponse dto.CheckLimi
 	return _c
 }
 
-func (_c *ILimitUseCase_CheckLimit_Call) RunAndReturn(run func(checkLimitRequest *dto.CheckLimitRequest) (dto.CheckLimitResponse, error)) *ILimitUseCase_CheckLimit_Call {
+func (_c *ILimitUseCase_CheckLimit_Call) RunAndReturn(run func(ctx context.Context, req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error)) *ILimitUseCase_CheckLimit_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ResetLimit provides a mock function for the type ILimitUseCase
-func (_mock *ILimitUseCase) ResetLimit(checkLimitRequest *dto.CheckLimitRequest) (dto.CheckLimitResponse, error) {
-	ret := _mock.Called(checkLimitRequest)
+func (_mock *ILimitUseCase) ResetLimit(ctx context.Context, req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ResetLimit")
@@ -107,16 +114,16 @@ func (_mock *ILimitUseCase) ResetLimit(checkLimitRequest *dto.CheckLimitRequest)
 
 	var r0 dto.CheckLimitResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*dto.CheckLimitRequest) (dto.CheckLimitResponse, error)); ok {
-		return returnFunc(checkLimitRequest)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.CheckLimitRequest) (dto.CheckLimitResponse, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*dto.CheckLimitRequest) dto.CheckLimitResponse); ok {
-		r0 = returnFunc(checkLimitRequest)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.CheckLimitRequest) dto.CheckLimitResponse); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		r0 = ret.Get(0).(dto.CheckLimitResponse)
 	}
-	if returnFunc, ok := ret.Get(1).(func(*dto.CheckLimitRequest) error); ok {
-		r1 = returnFunc(checkLimitRequest)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dto.CheckLimitRequest) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -129,19 +136,25 @@ type ILimitUseCase_ResetLimit_Call struct {
 }
 
 // ResetLimit is a helper method to define mock.On call
-//   - checkLimitRequest *dto.CheckLimitRequest
-func (_e *ILimitUseCase_Expecter) ResetLimit(checkLimitRequest interface{}) *ILimitUseCase_ResetLimit_Call {
-	return &ILimitUseCase_ResetLimit_Call{Call: _e.mock.On("ResetLimit", checkLimitRequest)}
+//   - ctx context.Context
+//   - req *dto.CheckLimitRequest
+func (_e *ILimitUseCase_Expecter) ResetLimit(ctx interface{}, req interface{}) *ILimitUseCase_ResetLimit_Call {
+	return &ILimitUseCase_ResetLimit_Call{Call: _e.mock.On("ResetLimit", ctx, req)}
 }
 
-func (_c *ILimitUseCase_ResetLimit_Call) Run(run func(checkLimitRequest *dto.CheckLimitRequest)) *ILimitUseCase_ResetLimit_Call {
+func (_c *ILimitUseCase_ResetLimit_Call) Run(run func(ctx context.Context, req *dto.CheckLimitRequest)) *ILimitUseCase_ResetLimit_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *dto.CheckLimitRequest
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*dto.CheckLimitRequest)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dto.CheckLimitRequest
+		if args[1] != nil {
+			arg1 = args[1].(*dto.CheckLimitRequest)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -152,7 +165,7 @@ func (_c *ILimitUseCase_ResetLimit_Call) Return(checkLimitResponse dto.CheckLimi
 	return _c
 }
 
-func (_c *ILimitUseCase_ResetLimit_Call) RunAndReturn(run func(checkLimitRequest *dto.CheckLimitRequest) (dto.CheckLimitResponse, error)) *ILimitUseCase_ResetLimit_Call {
+func (_c *ILimitUseCase_ResetLimit_Call) RunAndReturn(run func(ctx context.Context, req *dto.CheckLimitRequest) (dto.CheckLimitResponse, error)) *ILimitUseCase_ResetLimit_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1,11 +1,32 @@
 package user
 
 import (
+	"context"
+	"fmt"
+
 	"go-service-template/internal/api/dto"
 	"go-service-template/internal/domain/user"
+	"go-service-template/internal/infrastructure/tenant"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-func (s *UseCase) CreateUserRequest(_ *dto.CreateUserRequest) (*user.User, error) {
-	// Placeholder for reset logic
-	return &user.User{}, nil
+// CreateUserRequest saves the user of req for the tenant carried by ctx. The creation is
+// recorded once the repository has stored the user; without a repository nothing is
+// created.
+func (s *UseCase) CreateUserRequest(ctx context.Context, req *dto.CreateUserRequest) (_ *user.User, err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "user.CreateUserRequest")
+	defer func() { endSpan(span, err) }()
+	if req == nil || s.userRepository == nil {
+		return &user.User{}, nil
+	}
+	span.SetAttributes(attribute.Int(AttributeUserID, req.ID))
+
+	created, err := s.userRepository.Save(ctx, tenant.FromContext(ctx), *user.CreateNewUser(*req))
+	if err != nil {
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+	s.instrumentation.UserCreated(ctx)
+	return &created, nil
 }
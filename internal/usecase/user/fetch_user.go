@@ -1,11 +1,94 @@
 package user
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
 	"go-service-template/internal/api/dto"
 	"go-service-template/internal/domain/user"
+	"go-service-template/internal/infrastructure/logger"
+	"go-service-template/internal/infrastructure/tenant"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func (s *UseCase) FetchUser(_ *dto.FetchUserRequest) (*user.User, error) {
-	// Placeholder for rate limiting logic
-	return &user.User{}, nil
+// FetchUser returns the user of the tenant carried by ctx from the cache, or from the
+// repository on a miss, caching it for userCacheTTL. Without Redis every fetch goes to the
+// repository.
+func (s *UseCase) FetchUser(ctx context.Context, req *dto.FetchUserRequest) (_ *user.User, err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "user.FetchUser")
+	defer func() { endSpan(span, err) }()
+	if req == nil {
+		return &user.User{}, nil
+	}
+	span.SetAttributes(attribute.Int(AttributeUserID, req.ID))
+	tenantID := tenant.FromContext(ctx)
+	cached, hit := s.cachedUser(ctx, tenantID, req.ID)
+	span.SetAttributes(attribute.Bool(AttributeCacheHit, hit))
+	if hit {
+		return cached, nil
+	}
+
+	fetched := &user.User{}
+	if s.userRepository != nil {
+		u, err := s.userRepository.Fetch(ctx, tenantID, req.ID)
+		if err != nil {
+			return nil, fmt.Errorf("fetch user: %w", err)
+		}
+		fetched = &u
+	}
+	s.cacheUser(ctx, tenantID, req.ID, fetched)
+	return fetched, nil
+}
+
+func (s *UseCase) cachedUser(ctx context.Context, tenantID string, id int) (*user.User, bool) {
+	if !s.redisProvider.Ready() {
+		return nil, false
+	}
+	var cached user.User
+	data, err := s.redisProvider.GetClient().Get(ctx, s.userCacheKey(tenantID, id)).Bytes()
+	hit := err == nil && json.Unmarshal(data, &cached) == nil
+	s.instrumentation.UserCacheLookup(ctx, hit)
+	return &cached, hit
+}
+
+// cacheUser is best effort: a failure only costs a repository call on the next fetch.
+func (s *UseCase) cacheUser(ctx context.Context, tenantID string, id int, u *user.User) {
+	if !s.redisProvider.Ready() {
+		return
+	}
+	data, err := json.Marshal(u)
+	if err == nil {
+		err = s.redisProvider.GetClient().Set(ctx, s.userCacheKey(tenantID, id), data, userCacheTTL).Err()
+	}
+	if err != nil {
+		logger.Warn(ctx, "Failed to cache user", logger.ErrorField(logger.FieldError, err), logger.Int(logger.FieldUserID, id))
+	}
 }
+
+// endSpan ends span, recording err and an error status first when the operation failed.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (s *UseCase) userCacheKey(tenantID string, id int) string {
+	return s.redisProvider.Keys().Key(tenantID, userCacheKeyPart, strconv.Itoa(id))
+}
+
+const (
+	userCacheTTL      = 5 * time.Minute
+	userCacheKeyPart  = "user"
+	tracerName        = "go-service-template/internal/usecase/user"
+	AttributeUserID   = "user.id"
+	AttributeCacheHit = "user.cache_hit"
+)
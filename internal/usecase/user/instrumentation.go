@@ -0,0 +1,19 @@
+package user
+
+import "context"
+
+// Instrumentation receives the business events of the user use case. Implementations
+// must be safe for concurrent use.
+type Instrumentation interface {
+	// UserCreated records a successful user creation.
+	UserCreated(ctx context.Context)
+	// UserCacheLookup records whether a fetched user was found in the cache.
+	UserCacheLookup(ctx context.Context, hit bool)
+}
+
+// NopInstrumentation discards every event.
+type NopInstrumentation struct{}
+
+func (NopInstrumentation) UserCreated(context.Context) {}
+
+func (NopInstrumentation) UserCacheLookup(context.Context, bool) {}
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	"context"
 	"go-service-template/internal/api/dto"
 	"go-service-template/internal/domain/user"
 
@@ -39,8 +40,8 @@ func (_m *IUserUseCase) EXPECT() *IUserUseCase_Expecter {
 }
 
 // CreateUserRequest provides a mock function for the type IUserUseCase
-func (_mock *IUserUseCase) CreateUserRequest(req *dto.CreateUserRequest) (*user.User, error) {
-	ret := _mock.Called(req)
+func (_mock *IUserUseCase) CreateUserRequest(ctx context.Context, req *dto.CreateUserRequest) (*user.User, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateUserRequest")
@@ -48,18 +49,18 @@ func (_mock *IUserUseCase) CreateUserRequest(req *dto.CreateUserRequest) (*user.
 
 	var r0 *user.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*dto.CreateUserRequest) (*user.User, error)); ok {
-		return returnFunc(req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.CreateUserRequest) (*user.User, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*dto.CreateUserRequest) *user.User); ok {
-		r0 = returnFunc(req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.CreateUserRequest) *user.User); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*user.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*dto.CreateUserRequest) error); ok {
-		r1 = returnFunc(req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dto.CreateUserRequest) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -72,19 +73,25 @@ type IUserUseCase_CreateUserRequest_Call struct {
 }
 
 // CreateUserRequest is a helper method to define mock.On call
+//   - ctx context.Context
 //   - req *dto.CreateUserRequest
-func (_e *IUserUseCase_Expecter) CreateUserRequest(req interface{}) *IUserUseCase_CreateUserRequest_Call {
-	return &IUserUseCase_CreateUserRequest_Call{Call: _e.mock.On("CreateUserRequest", req)}
+func (_e *IUserUseCase_Expecter) CreateUserRequest(ctx interface{}, req interface{}) *IUserUseCase_CreateUserRequest_Call {
+	return &IUserUseCase_CreateUserRequest_Call{Call: _e.mock.On("CreateUserRequest", ctx, req)}
 }
 
-func (_c *IUserUseCase_CreateUserRequest_Call) Run(run func(req *dto.CreateUserRequest)) *IUserUseCase_CreateUserRequest_Call {
+func (_c *IUserUseCase_CreateUserRequest_Call) Run(run func(ctx context.Context, req *dto.CreateUserRequest)) *IUserUseCase_CreateUserRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *dto.CreateUserRequest
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*dto.CreateUserRequest)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dto.CreateUserRequest
+		if args[1] != nil {
+			arg1 = args[1].(*dto.CreateUserRequest)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -95,14 +102,14 @@ func (_c *IUserUseCase_CreateUserRequest_Call) Return(user1 *user.User, err erro
 	return _c
 }
 
-func (_c *IUserUseCase_CreateUserRequest_Call) RunAndReturn(run func(req *dto.CreateUserRequest) (*user.User, error)) *IUserUseCase_CreateUserRequest_Call {
+func (_c *IUserUseCase_CreateUserRequest_Call) RunAndReturn(run func(ctx context.Context, req *dto.CreateUserRequest) (*user.User, error)) *IUserUseCase_CreateUserRequest_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // FetchUser provides a mock function for the type IUserUseCase
-func (_mock *IUserUseCase) FetchUser(req *dto.FetchUserRequest) (*user.User, error) {
-	ret := _mock.Called(req)
+func (_mock *IUserUseCase) FetchUser(ctx context.Context, req *dto.FetchUserRequest) (*user.User, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FetchUser")
@@ -110,18 +117,18 @@ func (_mock *IUserUseCase) FetchUser(req *dto.FetchUserRequest) (*user.User, err
 
 	var r0 *user.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*dto.FetchUserRequest) (*user.User, error)); ok {
-		return returnFunc(req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.FetchUserRequest) (*user.User, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*dto.FetchUserRequest) *user.User); ok {
-		r0 = returnFunc(req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.FetchUserRequest) *user.User); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*user.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*dto.FetchUserRequest) error); ok {
-		r1 = returnFunc(req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dto.FetchUserRequest) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -134,19 +141,25 @@ type IUserUseCase_FetchUser_Call struct {
 }
 
 // FetchUser is a helper method to define mock.On call
+//   - ctx context.Context
 //   - req *dto.FetchUserRequest
-func (_e *IUserUseCase_Expecter) FetchUser(req interface{}) *IUserUseCase_FetchUser_Call {
-	return &IUserUseCase_FetchUser_Call{Call: _e.mock.On("FetchUser", req)}
+func (_e *IUserUseCase_Expecter) FetchUser(ctx interface{}, req interface{}) *IUserUseCase_FetchUser_Call {
+	return &IUserUseCase_FetchUser_Call{Call: _e.mock.On("FetchUser", ctx, req)}
 }
 
-func (_c *IUserUseCase_FetchUser_Call) Run(run func(req *dto.FetchUserRequest)) *IUserUseCase_FetchUser_Call {
+func (_c *IUserUseCase_FetchUser_Call) Run(run func(ctx context.Context, req *dto.FetchUserRequest)) *IUserUseCase_FetchUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *dto.FetchUserRequest
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*dto.FetchUserRequest)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dto.FetchUserRequest
+		if args[1] != nil {
+			arg1 = args[1].(*dto.FetchUserRequest)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -157,7 +170,7 @@ func (_c *IUserUseCase_FetchUser_Call) Return(user1 *user.User, err error) *IUse
 	return _c
 }
 
-func (_c *IUserUseCase_FetchUser_Call) RunAndReturn(run func(req *dto.FetchUserRequest) (*user.User, error)) *IUserUseCase_FetchUser_Call {
+func (_c *IUserUseCase_FetchUser_Call) RunAndReturn(run func(ctx context.Context, req *dto.FetchUserRequest) (*user.User, error)) *IUserUseCase_FetchUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
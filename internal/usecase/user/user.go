@@ -1,6 +1,8 @@
 package user
 
 import (
+	"context"
+
 	"go-service-template/internal/api/dto"
 	domain "go-service-template/internal/domain/user"
 	"go-service-template/internal/infrastructure/provider/redis"
@@ -11,17 +13,24 @@ type UseCase struct {
 	redisProvider      *redis.Provider
 	userRepository     repo.UserRepo
 	userWebAPIProvider repo.UserWebAPI
+	instrumentation    Instrumentation
 }
 
+// IUserUseCase works on the users of the tenant carried by ctx, see tenant.WithTenant.
 type IUserUseCase interface {
-	CreateUserRequest(req *dto.CreateUserRequest) (*domain.User, error)
-	FetchUser(req *dto.FetchUserRequest) (*domain.User, error)
+	CreateUserRequest(ctx context.Context, req *dto.CreateUserRequest) (*domain.User, error)
+	FetchUser(ctx context.Context, req *dto.FetchUserRequest) (*domain.User, error)
 }
 
-func NewUserUseCase(redisProvider *redis.Provider, userRepository repo.UserRepo, userWebAPIProvider repo.UserWebAPI) *UseCase {
+// NewUserUseCase creates the user use case. A nil instrumentation discards the business events.
+func NewUserUseCase(redisProvider *redis.Provider, userRepository repo.UserRepo, userWebAPIProvider repo.UserWebAPI, instrumentation Instrumentation) *UseCase {
+	if instrumentation == nil {
+		instrumentation = NopInstrumentation{}
+	}
 	return &UseCase{
 		redisProvider:      redisProvider,
 		userRepository:     userRepository,
 		userWebAPIProvider: userWebAPIProvider,
+		instrumentation:    instrumentation,
 	}
 }
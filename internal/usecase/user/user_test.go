@@ -1,17 +1,32 @@
 package user
 
 import (
+	"context"
 	"testing"
 
 	"go-service-template/internal/api/dto"
 	domain "go-service-template/internal/domain/user"
-
+	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/provider/redis"
+	"go-service-template/internal/infrastructure/repo"
+	repomocks "go-service-template/internal/infrastructure/repo/mocks"
+	"go-service-template/internal/infrastructure/tenant"
+	"go-service-template/internal/usecase/user/mocks"
+
+	"github.com/alicebob/miniredis/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 
 func TestNewUserUseCase_ValidInput_ReturnsUserUseCase(t *testing.T) {
-	useCase := NewUserUseCase(nil, nil, nil)
+	useCase := NewUserUseCase(nil, nil, nil, nil)
 
 	assert.NotNil(t, useCase)
 	assert.IsType(t, &UseCase{}, useCase)
@@ -21,7 +36,7 @@ func TestNewUserUseCase_ValidInput_ReturnsUserUseCase(t *testing.T) {
 }
 
 func TestNewUserUseCase_NilInputs_ReturnsUserUseCase(t *testing.T) {
-	useCase := NewUserUseCase(nil, nil, nil)
+	useCase := NewUserUseCase(nil, nil, nil, nil)
 
 	assert.NotNil(t, useCase)
 	assert.IsType(t, &UseCase{}, useCase)
@@ -31,7 +46,7 @@ func TestNewUserUseCase_NilInputs_ReturnsUserUseCase(t *testing.T) {
 }
 
 func TestUseCase_CreateUserRequest_ValidRequest_ReturnsUser(t *testing.T) {
-	useCase := NewUserUseCase(nil, nil, nil)
+	useCase := NewUserUseCase(nil, nil, nil, nil)
 
 	request := &dto.CreateUserRequest{
 		ID:    123,
@@ -40,7 +55,7 @@ func TestUseCase_CreateUserRequest_ValidRequest_ReturnsUser(t *testing.T) {
 		Age:   30,
 	}
 
-	user, err := useCase.CreateUserRequest(request)
+	user, err := useCase.CreateUserRequest(context.Background(), request)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
@@ -48,9 +63,9 @@ func TestUseCase_CreateUserRequest_ValidRequest_ReturnsUser(t *testing.T) {
 }
 
 func TestUseCase_CreateUserRequest_NilRequest_ReturnsUser(t *testing.T) {
-	useCase := NewUserUseCase(nil, nil, nil)
+	useCase := NewUserUseCase(nil, nil, nil, nil)
 
-	user, err := useCase.CreateUserRequest(nil)
+	user, err := useCase.CreateUserRequest(context.Background(), nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
@@ -58,10 +73,10 @@ func TestUseCase_CreateUserRequest_NilRequest_ReturnsUser(t *testing.T) {
 }
 
 func TestUseCase_CreateUserRequest_EmptyRequest_ReturnsUser(t *testing.T) {
-	useCase := NewUserUseCase(nil, nil, nil)
+	useCase := NewUserUseCase(nil, nil, nil, nil)
 
 	request := &dto.CreateUserRequest{}
-	user, err := useCase.CreateUserRequest(request)
+	user, err := useCase.CreateUserRequest(context.Background(), request)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
@@ -113,9 +128,9 @@ func TestUseCase_CreateUserRequest_EdgeCaseValues_ReturnsUser(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-					useCase := NewUserUseCase(nil, nil, nil)
+					useCase := NewUserUseCase(nil, nil, nil, nil)
 
-			user, err := useCase.CreateUserRequest(tt.request)
+			user, err := useCase.CreateUserRequest(context.Background(), tt.request)
 
 			assert.NoError(t, err)
 			assert.NotNil(t, user)
@@ -125,13 +140,13 @@ func TestUseCase_CreateUserRequest_EdgeCaseValues_ReturnsUser(t *testing.T) {
 }
 
 func TestUseCase_FetchUser_ValidRequest_ReturnsUser(t *testing.T) {
-			useCase := NewUserUseCase(nil, nil, nil)
+			useCase := NewUserUseCase(nil, nil, nil, nil)
 
 	request := &dto.FetchUserRequest{
 		ID: 123,
 	}
 
-	user, err := useCase.FetchUser(request)
+	user, err := useCase.FetchUser(context.Background(), request)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
@@ -139,9 +154,9 @@ func TestUseCase_FetchUser_ValidRequest_ReturnsUser(t *testing.T) {
 }
 
 func TestUseCase_FetchUser_NilRequest_ReturnsUser(t *testing.T) {
-			useCase := NewUserUseCase(nil, nil, nil)
+			useCase := NewUserUseCase(nil, nil, nil, nil)
 
-	user, err := useCase.FetchUser(nil)
+	user, err := useCase.FetchUser(context.Background(), nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
@@ -149,10 +164,10 @@ func TestUseCase_FetchUser_NilRequest_ReturnsUser(t *testing.T) {
 }
 
 func TestUseCase_FetchUser_EmptyRequest_ReturnsUser(t *testing.T) {
-			useCase := NewUserUseCase(nil, nil, nil)
+			useCase := NewUserUseCase(nil, nil, nil, nil)
 
 	request := &dto.FetchUserRequest{}
-	user, err := useCase.FetchUser(request)
+	user, err := useCase.FetchUser(context.Background(), request)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
@@ -198,9 +213,9 @@ func TestUseCase_FetchUser_EdgeCaseIDs_ReturnsUser(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-					useCase := NewUserUseCase(nil, nil, nil)
+					useCase := NewUserUseCase(nil, nil, nil, nil)
 
-			user, err := useCase.FetchUser(tt.request)
+			user, err := useCase.FetchUser(context.Background(), tt.request)
 
 			assert.NoError(t, err)
 			assert.NotNil(t, user)
@@ -210,14 +225,118 @@ func TestUseCase_FetchUser_EdgeCaseIDs_ReturnsUser(t *testing.T) {
 }
 
 func TestUseCase_InterfaceCompliance(t *testing.T) {
-	useCase := NewUserUseCase(nil, nil, nil)
+	useCase := NewUserUseCase(nil, nil, nil, nil)
 	var _ IUserUseCase = useCase
 	request := &dto.CreateUserRequest{ID: 1, Name: "Test", Email: "test@example.com", Age: 25}
 
-	_, err := useCase.CreateUserRequest(request)
+	_, err := useCase.CreateUserRequest(context.Background(), request)
 	assert.NoError(t, err)
 
 	fetchRequest := &dto.FetchUserRequest{ID: 1}
-	_, err = useCase.FetchUser(fetchRequest)
+	_, err = useCase.FetchUser(context.Background(), fetchRequest)
 	assert.NoError(t, err)
-}
\ No newline at end of file
+}
+
+func newCachedUserUseCase(t *testing.T, userRepository repo.UserRepo, instrumentation Instrumentation) *UseCase {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	t.Setenv(config.EnvRedisHost, mr.Addr())
+	provider, err := redis.NewProvider(config.NewConfig())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = provider.Close() })
+	require.NoError(t, provider.Ping(context.Background()), "Redis is skipped until it answers a ping")
+	return NewUserUseCase(provider, userRepository, nil, instrumentation)
+}
+
+func TestUseCase_FetchUser_CachesRepositoryResult(t *testing.T) {
+	userRepository := repomocks.NewUserRepo(t)
+	userRepository.EXPECT().Fetch(mock.Anything, "", 7).Return(domain.User{ID: 7, Name: "Alice"}, nil).Once()
+	instrumentation := mocks.NewInstrumentation(t)
+	instrumentation.EXPECT().UserCacheLookup(mock.Anything, false).Return().Once()
+	instrumentation.EXPECT().UserCacheLookup(mock.Anything, true).Return().Once()
+	useCase := newCachedUserUseCase(t, userRepository, instrumentation)
+
+	first, err := useCase.FetchUser(context.Background(), &dto.FetchUserRequest{ID: 7})
+	require.NoError(t, err)
+	second, err := useCase.FetchUser(context.Background(), &dto.FetchUserRequest{ID: 7})
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, "Alice", second.Name)
+}
+
+func TestUseCase_FetchUser_RepositoryError_NotCached(t *testing.T) {
+	userRepository := repomocks.NewUserRepo(t)
+	userRepository.EXPECT().Fetch(mock.Anything, "", 7).Return(domain.User{}, assert.AnError).Twice()
+	instrumentation := mocks.NewInstrumentation(t)
+	instrumentation.EXPECT().UserCacheLookup(mock.Anything, false).Return().Twice()
+	useCase := newCachedUserUseCase(t, userRepository, instrumentation)
+
+	_, err := useCase.FetchUser(context.Background(), &dto.FetchUserRequest{ID: 7})
+	require.ErrorIs(t, err, assert.AnError)
+	_, err = useCase.FetchUser(context.Background(), &dto.FetchUserRequest{ID: 7})
+	require.ErrorIs(t, err, assert.AnError)
+}
+
+func TestUseCase_FetchUser_CachesPerTenant(t *testing.T) {
+	userRepository := repomocks.NewUserRepo(t)
+	userRepository.EXPECT().Fetch(mock.Anything, "acme", 7).Return(domain.User{ID: 7, Name: "Alice"}, nil).Once()
+	userRepository.EXPECT().Fetch(mock.Anything, "globex", 7).Return(domain.User{ID: 7, Name: "Bob"}, nil).Once()
+	useCase := newCachedUserUseCase(t, userRepository, nil)
+
+	acme, err := useCase.FetchUser(tenant.WithTenant(context.Background(), "acme"), &dto.FetchUserRequest{ID: 7})
+	require.NoError(t, err)
+	globex, err := useCase.FetchUser(tenant.WithTenant(context.Background(), "globex"), &dto.FetchUserRequest{ID: 7})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Alice", acme.Name)
+	assert.Equal(t, "Bob", globex.Name)
+}
+
+func TestUseCase_CreateUserRequest_RecordsCreation(t *testing.T) {
+	userRepository := repomocks.NewUserRepo(t)
+	userRepository.EXPECT().Save(mock.Anything, "acme", domain.User{ID: 1, Name: "Alice"}).Return(domain.User{ID: 1, Name: "Alice"}, nil).Once()
+	instrumentation := mocks.NewInstrumentation(t)
+	instrumentation.EXPECT().UserCreated(mock.Anything).Return().Once()
+	useCase := NewUserUseCase(nil, userRepository, nil, instrumentation)
+
+	created, err := useCase.CreateUserRequest(tenant.WithTenant(context.Background(), "acme"), &dto.CreateUserRequest{ID: 1, Name: "Alice"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", created.Name)
+}
+
+func TestUseCase_CreateUserRequest_NotRecordedUnlessSaved(t *testing.T) {
+	userRepository := repomocks.NewUserRepo(t)
+	userRepository.EXPECT().Save(mock.Anything, "", mock.Anything).Return(domain.User{}, assert.AnError).Once()
+	instrumentation := mocks.NewInstrumentation(t)
+
+	_, err := NewUserUseCase(nil, userRepository, nil, instrumentation).
+		CreateUserRequest(context.Background(), &dto.CreateUserRequest{ID: 1})
+	require.ErrorIs(t, err, assert.AnError)
+
+	_, err = NewUserUseCase(nil, nil, nil, instrumentation).
+		CreateUserRequest(context.Background(), &dto.CreateUserRequest{ID: 1})
+	require.NoError(t, err)
+	instrumentation.AssertNotCalled(t, "UserCreated", mock.Anything)
+}
+
+func TestUseCase_FetchUser_TracesFetch(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	userRepository := repomocks.NewUserRepo(t)
+	userRepository.EXPECT().Fetch(mock.Anything, "", 7).Return(domain.User{}, assert.AnError).Once()
+	useCase := NewUserUseCase(nil, userRepository, nil, nil)
+
+	_, err := useCase.FetchUser(context.Background(), &dto.FetchUserRequest{ID: 7})
+
+	require.ErrorIs(t, err, assert.AnError)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "user.FetchUser", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.Int(AttributeUserID, 7))
+	assert.Contains(t, spans[0].Attributes(), attribute.Bool(AttributeCacheHit, false))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
@@ -5,7 +5,9 @@ import (
 	"net/http"
 
 	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/health"
 	"go-service-template/internal/infrastructure/logger"
+	"go-service-template/internal/infrastructure/metrics"
 	"go-service-template/server/resolver"
 	routerPkg "go-service-template/server/router"
 	"go-service-template/server/telemetry"
@@ -14,22 +16,43 @@ import (
 )
 
 type App struct {
-	config config.Provider
+	config *config.Watcher
 }
 
-func NewApp() *App {
+// NewApp builds the application around an already loaded configuration watcher.
+func NewApp(cfg *config.Watcher) *App {
 	return &App{
-		config: config.NewConfig(),
+		config: cfg,
 	}
 }
 
 func (app *App) Start() {
 	ctx := context.Background()
-	shutdown := telemetry.InitTracer(ctx, app.config)
+	app.configureSinks(ctx)
+	defer app.shutdownSinks(ctx)
+	app.watchConfig(ctx)
+
+	healthConfig := app.config.GetHealthConfig()
+	checks := health.NewRegistry(health.Config{Timeout: healthConfig.Timeout, CacheTTL: healthConfig.CacheTTL})
+
+	shutdown := telemetry.InitTracer(ctx, app.config, checks)
 	defer shutdown()
 
-	serverContext := resolver.NewResolver(app.config).ResolveServerContext()
-	router := app.createRouterAndRegisterRoutes(serverContext)
+	m := metrics.NewMetrics()
+	shutdownMeter := telemetry.InitMeter(ctx, app.config, m)
+	defer shutdownMeter()
+
+	serverContext := resolver.NewResolver(app.config, m, checks).ResolveServerContext()
+	router := app.createRouterAndRegisterRoutes(serverContext, m)
+
+	// The server starts while the dependencies are still connecting so that
+	// /health/startup can report their progress.
+	startup := app.config.GetStartupConfig()
+	checks.Start(ctx, health.StartupConfig{
+		Timeout:        startup.Timeout,
+		InitialBackoff: startup.InitialBackoff,
+		MaxBackoff:     startup.MaxBackoff,
+	})
 
 	logger.Info(ctx, "Starting HTTP server",
 		logger.String("host", app.config.GetServerHost()),
@@ -51,8 +74,88 @@ func (app *App) Start() {
 	}
 }
 
-func (app *App) createRouterAndRegisterRoutes(serverContext *resolver.ServerContext) *gin.Engine {
-	r := routerPkg.NewRouter(app.config).
+// watchConfig applies the configured log level and keeps runtime-tunable settings in sync
+// with the configuration file (and SIGHUP) for the lifetime of ctx.
+func (app *App) watchConfig(ctx context.Context) {
+	app.config.Subscribe("logger", configureLogger)
+	if err := configureLogger(app.config); err != nil {
+		logger.Error(ctx, "Invalid logger configuration", logger.ErrorField(logger.FieldError, err))
+	}
+
+	err := app.config.Watch(ctx, func(err error) {
+		if err != nil {
+			logger.Error(ctx, "Configuration reload failed", logger.ErrorField(logger.FieldError, err))
+			return
+		}
+		logger.Info(ctx, "Configuration reloaded", logger.String("log_level", app.config.GetLogLevel()))
+	})
+	if err != nil {
+		logger.Error(ctx, "Configuration watch disabled", logger.ErrorField(logger.FieldError, err))
+	}
+}
+
+// configureSinks sends logs to the configured sinks. Sinks are only read at startup.
+func (app *App) configureSinks(ctx context.Context) {
+	if err := logger.SetSinks(ctx, logSinks(app.config)); err != nil {
+		logger.Error(ctx, "Invalid log sinks, logging to stdout", logger.ErrorField(logger.FieldError, err))
+	}
+}
+
+func (app *App) shutdownSinks(ctx context.Context) {
+	if err := logger.Shutdown(ctx); err != nil {
+		logger.Error(ctx, "Error flushing log sinks", logger.ErrorField(logger.FieldError, err))
+	}
+}
+
+func logSinks(cfg config.Provider) []logger.SinkConfig {
+	sinks := make([]logger.SinkConfig, 0, len(cfg.GetLogSinks()))
+	for _, sink := range cfg.GetLogSinks() {
+		endpoint := sink.Endpoint
+		if endpoint == "" {
+			endpoint = cfg.GetOTLPEndpoint()
+		}
+		sinks = append(sinks, logger.SinkConfig{
+			Type:  sink.Type,
+			Level: sink.Level,
+			File: logger.FileSinkConfig{
+				Path:        sink.Path,
+				MaxSizeMB:   sink.MaxSizeMB,
+				MaxBackups:  sink.MaxBackups,
+				MaxAgeDays:  sink.MaxAgeDays,
+				Compress:    sink.Compress,
+				RotateEvery: sink.RotateEvery,
+			},
+			OTLP: logger.OTLPSinkConfig{
+				Endpoint:    endpoint,
+				Insecure:    !sink.TLS,
+				ServiceName: cfg.GetAppName(),
+				Environment: cfg.GetEnv(),
+			},
+		})
+	}
+	return sinks
+}
+
+// configureLogger applies the log level, redaction, sampling and deduplication settings to the global logger.
+func configureLogger(cfg config.Provider) error {
+	if err := logger.SetLevel(cfg.GetLogLevel()); err != nil {
+		return err
+	}
+	redaction := cfg.GetLogRedactionConfig()
+	if err := logger.SetRedaction(logger.RedactionConfig{Keys: redaction.Keys, Mode: redaction.Mode}); err != nil {
+		return err
+	}
+	sampling := cfg.GetLogSamplingConfig()
+	return logger.SetSampling(logger.SamplingConfig{
+		Initial:     sampling.Initial,
+		Thereafter:  sampling.Thereafter,
+		Routes:      sampling.Routes,
+		DedupWindow: sampling.DedupWindow,
+	})
+}
+
+func (app *App) createRouterAndRegisterRoutes(serverContext *resolver.ServerContext, m *metrics.Metrics) *gin.Engine {
+	r := routerPkg.NewRouter(app.config, m).
 		RegisterRoutes(serverContext).
 		Get()
 	return r
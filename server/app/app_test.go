@@ -6,6 +6,7 @@ import (
     "time"
 
     "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
     "go-service-template/internal/infrastructure/config"
 )
 
@@ -14,7 +15,10 @@ func TestApp_Start_ServesHealth(t *testing.T) {
     t.Setenv("HOST", "127.0.0.1")
     t.Setenv("PORT", "18081")
 
-    a := NewApp()
+    watcher, err := config.NewWatcher()
+    require.NoError(t, err)
+
+    a := NewApp(watcher)
     go a.Start()
 
     ok := assert.Eventually(t, func() bool {
@@ -2,16 +2,22 @@ package resolver
 
 import (
 	"context"
+	"errors"
 
 	"go-service-template/internal/api"
+	"go-service-template/internal/infrastructure/auth"
 	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/health"
 	"go-service-template/internal/infrastructure/logger"
+	"go-service-template/internal/infrastructure/metrics"
 	"go-service-template/internal/infrastructure/provider/redis"
 	"go-service-template/internal/infrastructure/repo"
 	"go-service-template/internal/infrastructure/repo/persistent"
 	"go-service-template/internal/infrastructure/repo/webapi"
 	"go-service-template/internal/usecase/limit"
 	"go-service-template/internal/usecase/user"
+
+	"github.com/evrone/go-clean-template/pkg/postgres"
 )
 
 type Resolver interface {
@@ -21,10 +27,16 @@ type Resolver interface {
 type ServerContext struct {
 	UserHandler    api.IUserHandler
 	LimiterHandler api.ILimiterHandler
+	HealthHandler  api.IHealthHandler
+	// Authenticators verify the credentials of API requests; nil when none is configured.
+	Authenticators *auth.Authenticators
+	// APIKeys manages the API keys; nil when they are disabled.
+	APIKeys auth.APIKeyManager
 }
 
 type Provider struct {
 	redisProvider      *redis.Provider
+	postgres           *postgres.Postgres
 	userRepo           repo.UserRepo
 	userWebAPIProvider repo.UserWebAPI
 }
@@ -32,14 +44,20 @@ type Provider struct {
 type resolver struct {
 	*Provider
 	*ServerContext
-	config config.Provider
+	config  config.Provider
+	metrics *metrics.Metrics
+	checks  *health.Registry
 }
 
-func NewResolver(cfg config.Provider) Resolver {
+// NewResolver creates the resolver. The use cases record their business metrics in m,
+// and the dependencies are registered in checks for the readiness probe.
+func NewResolver(cfg config.Provider, m *metrics.Metrics, checks *health.Registry) Resolver {
 	return &resolver{
 		Provider:      &Provider{},
 		ServerContext: &ServerContext{},
 		config:        cfg,
+		metrics:       m,
+		checks:        checks,
 	}
 }
 
@@ -54,39 +72,89 @@ func (r *resolver) ResolveServerContext() *ServerContext {
 	resolver = resolver.
 		repositories().
 		provider().
+		registerHealthChecks().
 		createServerContext()
 
 	return resolver.ServerContext
 }
 
 func (r *resolver) createServerContext() *resolver {
-	r.UserHandler = api.NewUserHandler(user.NewUserUseCase(r.redisProvider, r.userRepo, r.userWebAPIProvider))
-	r.LimiterHandler = api.NewLimiterHandler(limit.NewLimitUseCase(r.redisProvider))
+	instrumentation := metrics.NewInstrumentation(r.metrics)
+	r.UserHandler = api.NewUserHandler(user.NewUserUseCase(r.redisProvider, r.userRepo, r.userWebAPIProvider, instrumentation))
+	r.LimiterHandler = api.NewLimiterHandler(limit.NewLimitUseCase(r.redisProvider, r.config, instrumentation))
+	r.HealthHandler = api.NewHealthHandler(r.checks)
+	r.resolveAuth()
 	return r
 }
 
+// resolveAuth creates the authenticators of the credentials that are configured. Which
+// ones are accepted is decided at startup; the API keys themselves are reloadable.
+func (r *resolver) resolveAuth() {
+	cfg := r.config.GetAuthConfig()
+	authenticators := &auth.Authenticators{APIKeyHeader: cfg.APIKeys.Header}
+	if cfg.JWT.Enabled() {
+		authenticators.Bearer = auth.NewJWTAuthenticator(&cfg.JWT)
+	}
+	if cfg.APIKeys.Enabled() {
+		apiKeys := auth.NewAPIKeys(r.config, r.redisProvider)
+		authenticators.APIKey = apiKeys
+		r.APIKeys = apiKeys
+	}
+	if authenticators.Enabled() {
+		r.Authenticators = authenticators
+	}
+}
+
+// resolveProviders creates the Redis client and exports its pool statistics. It does not
+// wait for Redis: the startup phase retries the "redis" check until it connects.
 func (r *resolver) resolveProviders() *resolver {
 	ctx := context.Background()
 	redisProvider, err := redis.NewProvider(r.config)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to create redis provider, Error %s: ", err.Error())
-		logger.Error(ctx, "Please check your Redis configuration and ensure Redis is running")
-		logger.Error(ctx, "For local development, you can:")
-		logger.Error(ctx, "1. Start Redis locally: docker run -d -p 6379:6379 redis:alpine")
-		logger.Error(ctx, "2. Or set REDIS_HOST=localhost in your environment")
 		return nil
 	}
 	r.redisProvider = redisProvider
+	metrics.RegisterRedisPool(r.metrics, redisProvider.PoolStats)
 	logger.Info(ctx, "Redis provider initialized successfully")
 	return r
 }
 
 func (r *resolver) repositories() *resolver {
-	r.userRepo = persistent.NewUserRepo(nil)
+	r.userRepo = persistent.NewUserRepo(r.postgres)
 	return r
 }
 
 func (r *resolver) provider() *resolver {
-	r.userWebAPIProvider = webapi.NewUserWebAPI()
+	r.userWebAPIProvider = webapi.NewUserWebAPI(r.config.GetUserAPIConfig())
 	return r
 }
+
+// registerHealthChecks registers Redis and, when configured, Postgres as critical checks
+// and the user API as a non-critical one. Redis is checked even when the connection
+// failed at startup so the readiness probe reports it.
+func (r *resolver) registerHealthChecks() *resolver {
+	r.checks.Register(health.Check{Name: RedisCheckName, Critical: true, Probe: r.pingRedis})
+	if r.postgres != nil {
+		r.checks.Register(health.Check{Name: PostgresCheckName, Critical: true, Probe: r.userRepo.Ping})
+	}
+	if r.config.GetUserAPIConfig().BaseURL != "" {
+		r.checks.Register(health.Check{Name: UserAPICheckName, Probe: r.userWebAPIProvider.Ping})
+	}
+	return r
+}
+
+func (r *resolver) pingRedis(ctx context.Context) error {
+	if r.redisProvider == nil {
+		return ErrRedisNotConnected
+	}
+	return r.redisProvider.Ping(ctx)
+}
+
+const (
+	RedisCheckName    = "redis"
+	PostgresCheckName = "postgres"
+	UserAPICheckName  = "user_api"
+)
+
+var ErrRedisNotConnected = errors.New("redis not connected")
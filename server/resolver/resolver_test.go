@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/health"
+	"go-service-template/internal/infrastructure/metrics"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -11,14 +13,14 @@ import (
 func TestNewResolver_ReturnsResolver(t *testing.T) {
 	cfg := config.NewConfig()
 
-	r := NewResolver(cfg)
+	r := NewResolver(cfg, metrics.NewMetrics(), health.NewRegistry(health.Config{}))
 
 	assert.NotNil(t, r)
 }
 
 func TestResolveServerContext_ReturnsContext(t *testing.T) {
 	cfg := config.NewConfig()
-	r := NewResolver(cfg)
+	r := NewResolver(cfg, metrics.NewMetrics(), health.NewRegistry(health.Config{}))
 
 	ctx := r.ResolveServerContext()
 
@@ -2,12 +2,18 @@ package router
 
 import (
 	"context"
+	"crypto/subtle"
 	"go-service-template/internal/api"
+	"go-service-template/internal/infrastructure/auth"
 	"go-service-template/internal/infrastructure/config"
 	gincontext "go-service-template/internal/infrastructure/context"
 	"go-service-template/internal/infrastructure/logger"
+	"go-service-template/internal/infrastructure/metrics"
+	"go-service-template/internal/infrastructure/tenant"
 	"go-service-template/server/resolver"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
@@ -20,34 +26,97 @@ type IRouter interface {
 
 type Router struct {
 	*gin.Engine
-	config config.Provider
+	config  config.Provider
+	metrics *metrics.Metrics
 }
 
-func NewRouter(cfg config.Provider) *Router {
+// NewRouter creates the router, recording the HTTP metrics of every request in m.
+func NewRouter(cfg config.Provider, m *metrics.Metrics) *Router {
 	ctx := context.Background()
 	logger.Info(ctx, "Setting up endpoints...")
 
 	router := &Router{
-		Engine: gin.New(),
-		config: cfg,
+		Engine:  gin.New(),
+		config:  cfg,
+		metrics: m,
 	}
 
-	router.Use(logger.LoggingMiddleware())
+	// otelgin must run first so the logging middleware sees the request span.
 	router.Use(otelgin.Middleware(cfg.GetAppName()))
-	router.Use(corsMiddleware())
+	router.Use(router.metrics.Middleware())
+	router.Use(logger.LoggingMiddleware())
+	router.Use(logger.BodyCaptureMiddleware(bodyCapture(cfg)))
+	router.Use(corsMiddleware(cfg))
 	return router
 }
 
 func (r *Router) RegisterRoutes(serverContext *resolver.ServerContext) *Router {
-	healthHandler := api.NewHealthHandler()
-	r.GET("/health", WrapContext(healthHandler.Check))
-	r.POST("/api/v1/limit/check", WrapContext(serverContext.LimiterHandler.CheckLimit))
-	r.POST("/api/v1/limit/reset", WrapContext(serverContext.LimiterHandler.ResetLimit))
-	r.POST("/api/v1/user", WrapContext(serverContext.UserHandler.CreateUser))
-	r.GET("/api/v1/user/:id", WrapContext(serverContext.UserHandler.FetchUser))
+	// /health is kept as an alias of the liveness probe for existing deployments.
+	r.GET("/health", WrapContext(serverContext.HealthHandler.Live))
+	r.GET("/health/live", WrapContext(serverContext.HealthHandler.Live))
+	r.GET("/health/ready", WrapContext(serverContext.HealthHandler.Ready))
+	r.GET("/health/startup", WrapContext(serverContext.HealthHandler.Startup))
+	r.GET("/metrics", gin.WrapH(r.metrics.Handler()))
+	// Only the API is authenticated and tenant scoped: probes, metrics and admin endpoints
+	// serve the instance.
+	v1 := r.Group("/api/v1")
+	authorize := requireScopes
+	if serverContext.Authenticators != nil {
+		v1.Use(auth.Middleware(serverContext.Authenticators))
+	} else {
+		// config.Validate refuses to start without authentication outside the local and
+		// test profiles, so only those serve the API unauthenticated.
+		logger.Warn(context.Background(),
+			"API authentication disabled: no JWT secret, JWKS or API keys configured; scoped routes are open")
+		authorize = openRoute
+	}
+	v1.Use(tenant.Middleware(r.config))
+	// Each route declares the scopes its caller needs; admins may call them all.
+	v1.POST("/limit/check", authorize(auth.ScopeLimitCheck),
+		WrapContext(serverContext.LimiterHandler.CheckLimit))
+	v1.POST("/limit/reset", authorize(auth.ScopeLimitReset),
+		WrapContext(serverContext.LimiterHandler.ResetLimit))
+	v1.POST("/user", authorize(auth.ScopeUserWrite),
+		WrapContext(serverContext.UserHandler.CreateUser))
+	v1.GET("/user/:id", WrapContext(serverContext.UserHandler.FetchUser))
+	r.registerAdminRoutes(serverContext)
 	return r
 }
 
+// requireScopes requires scopes, or the admin role, of the callers of a route.
+func requireScopes(scopes ...string) gin.HandlerFunc {
+	return auth.Require(auth.Requirement{Scopes: scopes, Roles: []string{auth.RoleAdmin}})
+}
+
+// openRoute lets every caller through when authentication is not configured.
+func openRoute(...string) gin.HandlerFunc {
+	return func(*gin.Context) {}
+}
+
+// registerAdminRoutes exposes operational endpoints behind the admin token.
+// They are not registered at all when no token is configured.
+func (r *Router) registerAdminRoutes(serverContext *resolver.ServerContext) {
+	ctx := context.Background()
+	if r.config.GetAdminToken() == "" {
+		logger.Info(ctx, "Admin endpoints disabled: no admin token configured")
+		return
+	}
+
+	admin := r.Group(adminPath, adminAuthMiddleware(r.config))
+	if levels, ok := logger.GetLevelController(); ok {
+		logLevelHandler := api.NewLogLevelHandler(levels)
+		admin.GET("/log-level", WrapContext(logLevelHandler.Get))
+		admin.PUT("/log-level", WrapContext(logLevelHandler.Put))
+		admin.DELETE("/log-level", WrapContext(logLevelHandler.Delete))
+	}
+	if serverContext.APIKeys != nil {
+		apiKeyHandler := api.NewKeyHandler(serverContext.APIKeys)
+		admin.GET("/api-keys", WrapContext(apiKeyHandler.List))
+		admin.POST("/api-keys", WrapContext(apiKeyHandler.Issue))
+		admin.DELETE("/api-keys/:id", WrapContext(apiKeyHandler.Revoke))
+	}
+}
+
 func (r *Router) Get() *gin.Engine {
 	return r.Engine
 }
@@ -65,11 +134,63 @@ func WrapContext(handler func(ctx *gincontext.GinContext)) gin.HandlerFunc {
 	}
 }
 
-func corsMiddleware() gin.HandlerFunc {
+// adminAuthMiddleware checks the X-Admin-Token header against the current admin token.
+func adminAuthMiddleware(cfg config.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !validAdminToken(cfg, c) {
+			logger.Warn(logger.GetLogContext(c), "Rejected admin request",
+				logger.String(logger.FieldPath, c.Request.URL.Path),
+				logger.String(logger.FieldIP, c.ClientIP()),
+			)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid admin token"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// allowedHeaders lists the request headers browsers may send to the API: the standard
+// ones plus the configured API key and tenant headers.
+func allowedHeaders(cfg config.Provider) []string {
+	headers := []string{"Content-Type", "Access-Control-Allow-Headers", "Authorization", "X-Requested-With"}
+	for _, header := range []string{cfg.GetAuthConfig().APIKeys.Header, cfg.GetTenancyConfig().Header} {
+		if header != "" && !slices.Contains(headers, header) {
+			headers = append(headers, header)
+		}
+	}
+	return headers
+}
+
+// validAdminToken reports whether the request carries the configured admin token.
+func validAdminToken(cfg config.Provider, c *gin.Context) bool {
+	token := cfg.GetAdminToken()
+	provided := c.GetHeader(XAdminToken)
+	return token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
+
+// bodyCapture selects the requests whose bodies are logged: the configured routes, and
+// requests asking for it with X-Debug-Body and a valid admin token. Admin endpoints are
+// never captured, as their responses carry credentials such as issued API keys.
+func bodyCapture(cfg config.Provider) func(c *gin.Context) (logger.BodyCaptureConfig, bool) {
+	return func(c *gin.Context) (logger.BodyCaptureConfig, bool) {
+		body := cfg.GetLogBodyConfig()
+		capture := !strings.HasPrefix(c.FullPath(), adminPath+"/") &&
+			(slices.Contains(body.Routes, c.FullPath()) ||
+				(c.GetHeader(XDebugBody) != "" && validAdminToken(cfg, c)))
+		return logger.BodyCaptureConfig{MaxBytes: body.MaxBytes, Headers: body.Headers}, capture
+	}
+}
+
+// corsMiddleware reads the allowlist and the configured header names on every request so
+// configuration reloads apply immediately.
+func corsMiddleware(cfg config.Provider) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
+		if origin := allowedOrigin(cfg.GetCORSAllowedOrigins(), c.GetHeader("Origin")); origin != "" {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+		c.Writer.Header().Add("Vary", "Origin")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", strings.Join(allowedHeaders(cfg), ", "))
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusNoContent)
@@ -78,3 +199,23 @@ func corsMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// allowedOrigin returns the Access-Control-Allow-Origin value for origin, or "" if it is not allowed.
+func allowedOrigin(allowlist []string, origin string) string {
+	for _, allowed := range allowlist {
+		if allowed == config.WildcardOrigin {
+			return config.WildcardOrigin
+		}
+		if origin != "" && strings.EqualFold(allowed, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+const (
+	XAdminToken = "X-Admin-Token"
+	XDebugBody  = "X-Debug-Body"
+)
+
+const adminPath = "/admin"
@@ -3,18 +3,26 @@ package router
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/health"
 	gincontext "go-service-template/internal/infrastructure/context"
+	"go-service-template/internal/infrastructure/logger"
+	"go-service-template/internal/infrastructure/metrics"
 	"go-service-template/server/resolver"
 
 	"github.com/gin-gonic/gin"
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCORSHeaders_Options_NoContent(t *testing.T) {
-	mw := corsMiddleware()
+	mw := corsMiddleware(config.NewConfig())
 	rr := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodOptions, "/any", nil)
 	c, _ := gin.CreateTestContext(rr)
@@ -25,6 +33,20 @@ func TestCORSHeaders_Options_NoContent(t *testing.T) {
 	assert.NotEmpty(t, rr.Header().Get("Access-Control-Allow-Origin"))
 }
 
+func TestCORSHeaders_AllowHeaders_IncludeConfiguredHeaders(t *testing.T) {
+	t.Setenv(config.EnvAPIKeyHeader, "X-Service-Key")
+	t.Setenv(config.EnvTenantHeader, "X-Org")
+	mw := corsMiddleware(config.NewConfig())
+	rr := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = httptest.NewRequest(http.MethodOptions, "/any", nil)
+
+	mw(c)
+
+	assert.Equal(t, "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With, X-Service-Key, X-Org",
+		rr.Header().Get("Access-Control-Allow-Headers"))
+}
+
 func TestWrapContext_CallsHandler(t *testing.T) {
 	called := false
 	wrapped := WrapContext(func(ctx *gincontext.GinContext) { called = true })
@@ -38,8 +60,9 @@ func TestWrapContext_CallsHandler(t *testing.T) {
 
 func TestRegisterRoutes_Health_OK(t *testing.T) {
 	cfg := config.NewConfig()
-	r := NewRouter(cfg)
-	srvCtx := resolver.NewResolver(cfg).ResolveServerContext()
+	m := metrics.NewMetrics()
+	r := NewRouter(cfg, m)
+	srvCtx := resolver.NewResolver(cfg, m, health.NewRegistry(health.Config{})).ResolveServerContext()
 	r.RegisterRoutes(srvCtx)
 	engine := r.Get()
 	rr := httptest.NewRecorder()
@@ -47,3 +70,223 @@ func TestRegisterRoutes_Health_OK(t *testing.T) {
 	engine.ServeHTTP(rr, req)
 	assert.Equal(t, http.StatusOK, rr.Code)
 }
+
+func TestRegisterRoutes_HealthProbes_ReportRedisOutage(t *testing.T) {
+	t.Setenv(config.EnvRedisHost, "127.0.0.1:0")
+	cfg := config.NewConfig()
+	m := metrics.NewMetrics()
+	engine := NewRouter(cfg, m).RegisterRoutes(resolver.NewResolver(cfg, m, health.NewRegistry(health.Config{})).ResolveServerContext()).Get()
+
+	live := httptest.NewRecorder()
+	engine.ServeHTTP(live, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+	ready := httptest.NewRecorder()
+	engine.ServeHTTP(ready, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	assert.Equal(t, http.StatusOK, live.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, ready.Code)
+	assert.Contains(t, ready.Body.String(), `"redis":{"status":"down","critical":true`)
+	assert.Contains(t, ready.Body.String(), "connection refused")
+}
+
+func TestCORSHeaders_Allowlist_EchoesAllowedOrigin(t *testing.T) {
+	t.Setenv(config.EnvCORSAllowedOrigins, "https://allowed.example")
+	mw := corsMiddleware(config.NewConfig())
+	rr := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = httptest.NewRequest(http.MethodGet, "/any", nil)
+	c.Request.Header.Set("Origin", "https://allowed.example")
+
+	mw(c)
+
+	assert.Equal(t, "https://allowed.example", rr.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSHeaders_Allowlist_RejectsUnknownOrigin(t *testing.T) {
+	t.Setenv(config.EnvCORSAllowedOrigins, "https://allowed.example")
+	mw := corsMiddleware(config.NewConfig())
+	rr := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rr)
+	c.Request = httptest.NewRequest(http.MethodGet, "/any", nil)
+	c.Request.Header.Set("Origin", "https://evil.example")
+
+	mw(c)
+
+	assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func serveAdmin(t *testing.T, token, header string) int {
+	t.Helper()
+	t.Setenv(config.EnvAdminToken, token)
+	cfg := config.NewConfig()
+	m := metrics.NewMetrics()
+	engine := NewRouter(cfg, m).RegisterRoutes(resolver.NewResolver(cfg, m, health.NewRegistry(health.Config{})).ResolveServerContext()).Get()
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin/log-level", nil)
+	if header != "" {
+		req.Header.Set(XAdminToken, header)
+	}
+	engine.ServeHTTP(rr, req)
+	return rr.Code
+}
+
+func TestAdminRoutes_ValidToken_OK(t *testing.T) {
+	assert.Equal(t, http.StatusOK, serveAdmin(t, "s3cret", "s3cret"))
+}
+
+func TestAdminRoutes_WrongToken_Unauthorized(t *testing.T) {
+	assert.Equal(t, http.StatusUnauthorized, serveAdmin(t, "s3cret", "guess"))
+	assert.Equal(t, http.StatusUnauthorized, serveAdmin(t, "s3cret", ""))
+}
+
+func TestAdminRoutes_NoTokenConfigured_NotRegistered(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, serveAdmin(t, "", "anything"))
+}
+
+func TestBodyCapture_SelectsRoutesAndDebugRequests(t *testing.T) {
+	t.Setenv(config.EnvAdminToken, "s3cret")
+	t.Setenv(config.EnvLogBodyRoutes, "/api/v1/user,/admin/api-keys")
+	capture := bodyCapture(config.NewConfig())
+
+	tests := []struct {
+		name    string
+		route   string
+		headers map[string]string
+		want    bool
+	}{
+		{name: "ConfiguredRoute", route: "/api/v1/user", want: true},
+		{name: "OtherRoute", route: "/health", want: false},
+		{name: "DebugHeaderWithToken", route: "/health", headers: map[string]string{XDebugBody: "1", XAdminToken: "s3cret"}, want: true},
+		{name: "DebugHeaderWrongToken", route: "/health", headers: map[string]string{XDebugBody: "1", XAdminToken: "guess"}, want: false},
+		{name: "TokenWithoutDebugHeader", route: "/health", headers: map[string]string{XAdminToken: "s3cret"}, want: false},
+		{name: "ConfiguredAdminRoute", route: "/admin/api-keys", want: false},
+		{name: "DebugHeaderOnAdminRoute", route: "/admin/api-keys", headers: map[string]string{XDebugBody: "1", XAdminToken: "s3cret"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			var got bool
+			var cfg logger.BodyCaptureConfig
+			engine.POST(tt.route, func(c *gin.Context) { cfg, got = capture(c) })
+			req := httptest.NewRequest(http.MethodPost, tt.route, nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			engine.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, config.DefaultLogBodyMaxBytes, cfg.MaxBytes)
+		})
+	}
+}
+
+func TestMetrics_ExposesRequestsByRouteTemplate(t *testing.T) {
+	cfg := config.NewConfig()
+	m := metrics.NewMetrics()
+	engine := NewRouter(cfg, m).RegisterRoutes(resolver.NewResolver(cfg, m, health.NewRegistry(health.Config{})).ResolveServerContext()).Get()
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	rr := httptest.NewRecorder()
+	engine.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `http_requests_total{method="GET",route="/health",status="200"} 1`)
+	assert.Contains(t, rr.Body.String(), `redis_pool_connections{state="total"}`)
+}
+
+func TestRegisterRoutes_TenantScopesOnlyTheAPI(t *testing.T) {
+	t.Setenv(config.EnvTenantDefault, "")
+	cfg := config.NewConfig()
+	m := metrics.NewMetrics()
+	engine := NewRouter(cfg, m).RegisterRoutes(resolver.NewResolver(cfg, m, health.NewRegistry(health.Config{})).ResolveServerContext()).Get()
+
+	api := httptest.NewRecorder()
+	engine.ServeHTTP(api, httptest.NewRequest(http.MethodGet, "/api/v1/user/7", nil))
+	probe := httptest.NewRecorder()
+	engine.ServeHTTP(probe, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	assert.Equal(t, http.StatusBadRequest, api.Code)
+	assert.Equal(t, http.StatusOK, probe.Code)
+}
+
+func TestRegisterRoutes_JWTConfigured_AuthenticatesOnlyTheAPI(t *testing.T) {
+	t.Setenv(config.EnvJWTSecret, "0123456789abcdef0123456789abcdef")
+	cfg := config.NewConfig()
+	m := metrics.NewMetrics()
+	engine := NewRouter(cfg, m).RegisterRoutes(resolver.NewResolver(cfg, m, health.NewRegistry(health.Config{})).ResolveServerContext()).Get()
+
+	api := httptest.NewRecorder()
+	engine.ServeHTTP(api, httptest.NewRequest(http.MethodGet, "/api/v1/user/7", nil))
+	probe := httptest.NewRecorder()
+	engine.ServeHTTP(probe, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, api.Code)
+	assert.Equal(t, http.StatusOK, probe.Code)
+}
+
+func TestRegisterRoutes_APIKeysConfigured_RegistersAdminEndpoints(t *testing.T) {
+	t.Setenv(config.EnvAPIKeysRedis, "true")
+	t.Setenv(config.EnvAdminToken, "s3cret")
+	cfg := config.NewConfig()
+	m := metrics.NewMetrics()
+	engine := NewRouter(cfg, m).RegisterRoutes(resolver.NewResolver(cfg, m, health.NewRegistry(health.Config{})).ResolveServerContext()).Get()
+
+	api := httptest.NewRecorder()
+	engine.ServeHTTP(api, httptest.NewRequest(http.MethodGet, "/api/v1/user/7", nil))
+	admin := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin/api-keys", nil)
+	req.Header.Set(XAdminToken, "s3cret")
+	engine.ServeHTTP(admin, req)
+
+	assert.Equal(t, http.StatusUnauthorized, api.Code)
+	assert.Equal(t, `APIKey header="X-API-Key"`, api.Header().Get("WWW-Authenticate"))
+	assert.NotEqual(t, http.StatusNotFound, admin.Code)
+}
+
+func signedToken(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte(secret)}, nil)
+	require.NoError(t, err)
+	token, err := jwt.Signed(signer).
+		Claims(jwt.Claims{Subject: "user-1", Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour))}).
+		Claims(claims).
+		Serialize()
+	require.NoError(t, err)
+	return token
+}
+
+func TestRegisterRoutes_JWTConfigured_AuthorizesRoutesByScope(t *testing.T) {
+	const secret = "0123456789abcdef0123456789abcdef"
+	t.Setenv(config.EnvJWTSecret, secret)
+	cfg := config.NewConfig()
+	m := metrics.NewMetrics()
+	engine := NewRouter(cfg, m).RegisterRoutes(resolver.NewResolver(cfg, m, health.NewRegistry(health.Config{})).ResolveServerContext()).Get()
+	serve := func(path string, claims map[string]any) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}"))
+		req.Header.Set("Authorization", "Bearer "+signedToken(t, secret, claims))
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	denied := serve("/api/v1/limit/reset", map[string]any{"scope": "limit:check"})
+	assert.Equal(t, http.StatusForbidden, denied.Code)
+	assert.Equal(t, "application/problem+json", denied.Header().Get("Content-Type"))
+	assert.Equal(t, http.StatusForbidden, serve("/api/v1/user", map[string]any{}).Code)
+	assert.NotEqual(t, http.StatusForbidden, serve("/api/v1/limit/reset", map[string]any{"scope": "limit:reset"}).Code)
+	assert.NotEqual(t, http.StatusForbidden, serve("/api/v1/user", map[string]any{"roles": []string{"admin"}}).Code)
+}
+
+func TestRegisterRoutes_NoAuthConfigured_ScopedRoutesOpen(t *testing.T) {
+	cfg := config.NewConfig()
+	m := metrics.NewMetrics()
+	engine := NewRouter(cfg, m).RegisterRoutes(resolver.NewResolver(cfg, m, health.NewRegistry(health.Config{})).ResolveServerContext()).Get()
+
+	for _, path := range []string{"/api/v1/limit/check", "/api/v1/limit/reset", "/api/v1/user"} {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"userID":1}`)))
+
+		assert.NotEqual(t, http.StatusForbidden, w.Code, path)
+		assert.NotEqual(t, http.StatusUnauthorized, w.Code, path)
+	}
+}
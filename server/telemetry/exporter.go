@@ -0,0 +1,54 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go-service-template/internal/infrastructure/config"
+)
+
+// exporterKinds creates the exporter of one signal, spans or metrics, for each exporter
+// kind, so that every signal follows the kind selected in the tracing configuration.
+type exporterKinds[E any] interface {
+	otlpGRPC() (E, error)
+	otlpHTTP() (E, error)
+	stdout() (E, error)
+}
+
+// newExporter returns the exporter of kinds selected by cfg, or the zero E for
+// TraceExporterNone.
+func newExporter[E any](cfg config.TracingConfig, kinds exporterKinds[E]) (E, error) {
+	var none E
+	switch cfg.Exporter {
+	case config.TraceExporterOTLPGRPC:
+		return kinds.otlpGRPC()
+	case config.TraceExporterOTLPHTTP:
+		return kinds.otlpHTTP()
+	case config.TraceExporterStdout:
+		return kinds.stdout()
+	case config.TraceExporterNone:
+		return none, nil
+	default:
+		return none, fmt.Errorf("%w: %q", ErrUnknownExporter, cfg.Exporter)
+	}
+}
+
+// exporterTarget is where the OTLP exporters of every signal send to.
+type exporterTarget struct {
+	ctx      context.Context
+	cfg      config.TracingConfig
+	endpoint string
+}
+
+// otlpOptions returns the options of an OTLP exporter sending to endpoint with the headers
+// of cfg, in plaintext unless cfg enables TLS. The option constructors come from the
+// exporter package of the signal and transport.
+func otlpOptions[O any](cfg config.TracingConfig, endpoint string,
+	withEndpoint func(string) O, withHeaders func(map[string]string) O, withInsecure func() O,
+) []O {
+	options := []O{withEndpoint(endpoint), withHeaders(cfg.Headers)}
+	if !cfg.TLS {
+		options = append(options, withInsecure())
+	}
+	return options
+}
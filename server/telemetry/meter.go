@@ -0,0 +1,95 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/logger"
+	"go-service-template/internal/infrastructure/metrics"
+
+	prombridge "go.opentelemetry.io/contrib/bridges/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// InitMeter sets the global MeterProvider, built from the same resource as the tracer.
+// The metrics of m, HTTP and business ones included, are pushed with those of
+// OpenTelemetry instruments through the exporter, endpoint, TLS and headers configured
+// for traces, and the instruments are also served on /metrics by m. Nothing is pushed
+// with the none exporter, and metrics stay available on /metrics only when the pipeline
+// cannot be set up.
+func InitMeter(ctx context.Context, cfg config.Provider, m *metrics.Metrics) func() {
+	mp, err := createMeterProvider(ctx, cfg, m)
+	if err != nil {
+		logger.Error(ctx, "Failed to set up the OpenTelemetry meter provider", logger.ErrorField(logger.FieldError, err))
+		return func() {}
+	}
+	otel.SetMeterProvider(mp)
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), meterShutdownTimeout)
+		defer cancel()
+		if err := mp.Shutdown(shutdownCtx); err != nil {
+			logger.Error(ctx, "Error shutting down meter provider", logger.ErrorField(logger.FieldError, err))
+		}
+	}
+}
+
+func createMeterProvider(ctx context.Context, cfg config.Provider, m *metrics.Metrics) (*sdkmetric.MeterProvider, error) {
+	res, err := createResource(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create resource: %w", err)
+	}
+	exporter, err := newMetricExporter(ctx, cfg.GetTracingConfig(), cfg.GetOTLPEndpoint())
+	if err != nil {
+		return nil, fmt.Errorf("create metric exporter: %w", err)
+	}
+	return newMeterProvider(res, m, exporter)
+}
+
+// newMetricExporter creates the metric counterpart of the span exporter selected by cfg,
+// with the same transport settings; it returns nil for TraceExporterNone.
+func newMetricExporter(ctx context.Context, cfg config.TracingConfig, endpoint string) (sdkmetric.Exporter, error) {
+	return newExporter[sdkmetric.Exporter](cfg, &metricExporters{ctx: ctx, cfg: cfg, endpoint: endpoint})
+}
+
+// metricExporters creates the metric exporter of each kind.
+type metricExporters exporterTarget
+
+func (t *metricExporters) otlpGRPC() (sdkmetric.Exporter, error) {
+	return otlpmetricgrpc.New(t.ctx, otlpOptions(t.cfg, t.endpoint,
+		otlpmetricgrpc.WithEndpoint, otlpmetricgrpc.WithHeaders, otlpmetricgrpc.WithInsecure)...)
+}
+
+func (t *metricExporters) otlpHTTP() (sdkmetric.Exporter, error) {
+	return otlpmetrichttp.New(t.ctx, otlpOptions(t.cfg, t.endpoint,
+		otlpmetrichttp.WithEndpoint, otlpmetrichttp.WithHeaders, otlpmetrichttp.WithInsecure)...)
+}
+
+func (*metricExporters) stdout() (sdkmetric.Exporter, error) {
+	return stdoutmetric.New()
+}
+
+// newMeterProvider reads OpenTelemetry instruments with up to two readers: a periodic one
+// pushing to exporter, which also bridges the Prometheus registry of m, and one
+// exposing the instruments to Prometheus through m. A nil exporter pushes nothing.
+func newMeterProvider(res *resource.Resource, m *metrics.Metrics, exporter sdkmetric.Exporter) (*sdkmetric.MeterProvider, error) {
+	promExporter, err := otelprom.New(otelprom.WithRegisterer(m.OTelRegisterer()))
+	if err != nil {
+		return nil, fmt.Errorf("create Prometheus exporter: %w", err)
+	}
+	options := []sdkmetric.Option{sdkmetric.WithResource(res), sdkmetric.WithReader(promExporter)}
+	if exporter != nil {
+		bridge := prombridge.NewMetricProducer(prombridge.WithGatherer(m.Registry()))
+		options = append(options, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithProducer(bridge))))
+	}
+	return sdkmetric.NewMeterProvider(options...), nil
+}
+
+const meterShutdownTimeout = 5 * time.Second
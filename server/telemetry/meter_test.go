@@ -0,0 +1,115 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// recordingExporter keeps the names of the metrics it was asked to export.
+type recordingExporter struct {
+	mu    sync.Mutex
+	names map[string]int
+}
+
+func (e *recordingExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(kind)
+}
+
+func (e *recordingExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+func (e *recordingExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			e.names[m.Name]++
+		}
+	}
+	return nil
+}
+
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+func (e *recordingExporter) Shutdown(context.Context) error { return nil }
+
+func TestNewMeterProvider_ExportsRegistryAndInstruments(t *testing.T) {
+	m := metrics.NewMetrics()
+	exporter := &recordingExporter{names: map[string]int{}}
+	mp, err := newMeterProvider(resource.NewSchemaless(semconv.ServiceName("test")), m, exporter)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mp.Shutdown(context.Background()) })
+	metrics.NewInstrumentation(m).UserCreated(context.Background())
+	counter, err := mp.Meter("test").Int64Counter("otel_events")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 1)
+
+	require.NoError(t, mp.ForceFlush(context.Background()))
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, 1, exporter.names["user_creations_total"])
+	assert.Equal(t, 1, exporter.names["go_goroutines"])
+	assert.Equal(t, 1, exporter.names["otel_events"])
+	assert.Contains(t, rr.Body.String(), `otel_events_total{otel_scope_name="test"`)
+	assert.Contains(t, rr.Body.String(), "user_creations_total 1")
+}
+
+func TestNewMetricExporter_FollowsTraceExporter(t *testing.T) {
+	tests := []struct {
+		exporter string
+		wantNil  bool
+	}{
+		{exporter: config.TraceExporterOTLPGRPC},
+		{exporter: config.TraceExporterOTLPHTTP},
+		{exporter: config.TraceExporterStdout},
+		{exporter: config.TraceExporterNone, wantNil: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.exporter, func(t *testing.T) {
+			cfg := config.TracingConfig{Exporter: tt.exporter, TLS: true, Headers: map[string]string{"api-key": "k"}}
+			exporter, err := newMetricExporter(context.Background(), cfg, "localhost:4317")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNil, exporter == nil)
+			if exporter != nil {
+				assert.NoError(t, exporter.Shutdown(context.Background()))
+			}
+		})
+	}
+}
+
+func TestNewMetricExporter_UnknownExporter(t *testing.T) {
+	_, err := newMetricExporter(context.Background(), config.TracingConfig{Exporter: "zipkin"}, "")
+
+	assert.ErrorIs(t, err, ErrUnknownExporter)
+}
+
+func TestNewMeterProvider_NoExporter_ServesInstruments(t *testing.T) {
+	m := metrics.NewMetrics()
+	mp, err := newMeterProvider(resource.NewSchemaless(semconv.ServiceName("test")), m, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mp.Shutdown(context.Background()) })
+	counter, err := mp.Meter("test").Int64Counter("otel_events")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 1)
+
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Contains(t, rr.Body.String(), `otel_events_total{otel_scope_name="test"`)
+}
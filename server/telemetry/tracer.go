@@ -2,13 +2,20 @@ package telemetry
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
 
 	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/health"
+	"go-service-template/internal/infrastructure/logger"
 
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -19,34 +26,39 @@ import (
 type TracerBuilder struct {
 	ctx      context.Context
 	cfg      config.Provider
-	exporter *otlptrace.Exporter
+	exporter *monitoredExporter
 	err      error
 	resource *resource.Resource
 	tp       *sdktrace.TracerProvider
 }
 
-func InitTracer(ctx context.Context, cfg config.Provider) func() {
+// InitTracer sets the global TracerProvider and propagator, and registers a non-critical
+// "tracer" check in checks reporting export failures. When the exporter or the resource
+// cannot be created, the error is logged and tracing stays a no-op rather than stopping
+// the service.
+func InitTracer(ctx context.Context, cfg config.Provider, checks *health.Registry) func() {
 	return (&TracerBuilder{}).
-		createTelemetryExporter(ctx, cfg.GetOTLPEndpoint()).
+		createTelemetryExporter(ctx, cfg).
 		createApplicationResource(ctx, cfg).
 		createTraceProvider().
 		SetTracerProvider().
 		setTextMapPropagator().
+		registerHealthCheck(checks).
 		shutdownFunction()
 }
 
-// createTelemetryExporter creates the OTLP trace exporter.
-func (tb *TracerBuilder) createTelemetryExporter(ctx context.Context, endpoint string) *TracerBuilder {
+// createTelemetryExporter creates the configured span exporter; none creates no exporter.
+func (tb *TracerBuilder) createTelemetryExporter(ctx context.Context, cfg config.Provider) *TracerBuilder {
 	tb.ctx = ctx
-	exp, err := otlptracegrpc.New(ctx,
-		otlptracegrpc.WithInsecure(),
-		otlptracegrpc.WithEndpoint(endpoint),
-	)
+	tb.cfg = cfg
+	exp, err := newSpanExporter(ctx, cfg.GetTracingConfig(), cfg.GetOTLPEndpoint())
 	if err != nil {
-		log.Fatalf("failed to create trace exporter: %v", err)
+		tb.err = fmt.Errorf("create trace exporter: %w", err)
+		return tb
+	}
+	if exp != nil {
+		tb.exporter = &monitoredExporter{SpanExporter: exp}
 	}
-	tb.exporter = exp
-	tb.err = err
 	return tb
 }
 
@@ -56,27 +68,30 @@ func (tb *TracerBuilder) createApplicationResource(ctx context.Context, cfg conf
 		return tb
 	}
 
-	tb.cfg = cfg
 	res, err := createResource(ctx, cfg)
 	if err != nil {
-		log.Fatalf("failed to create resource: %v", err)
+		tb.err = fmt.Errorf("create resource: %w", err)
+		return tb
 	}
 	tb.resource = res
 	return tb
 }
 
-// createTraceProvider creates the trace provider.
+// createTraceProvider creates the trace provider. Without an exporter spans are still
+// sampled and propagated, so logs keep their trace IDs, but they are not exported.
 func (tb *TracerBuilder) createTraceProvider() *TracerBuilder {
 	if tb.existError() {
 		return tb
 	}
 
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(tb.exporter),
+	options := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(tb.resource),
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
-	)
-	tb.tp = tp
+		sdktrace.WithSampler(sampler(tb.cfg.GetTracingConfig())),
+	}
+	if tb.exporter != nil {
+		options = append(options, sdktrace.WithBatcher(tb.exporter))
+	}
+	tb.tp = sdktrace.NewTracerProvider(options...)
 	return tb
 }
 
@@ -90,34 +105,143 @@ func (tb *TracerBuilder) SetTracerProvider() *TracerBuilder {
 	return tb
 }
 
-// setTextMapPropagator sets the text map propagator.
+// setTextMapPropagator sets the text map propagator. It is set even when tracing is a
+// no-op so incoming trace context is still passed on to downstream services.
 func (tb *TracerBuilder) setTextMapPropagator() *TracerBuilder {
-	if tb.existError() {
-		return tb
-	}
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
 		propagation.TraceContext{}, propagation.Baggage{},
 	))
 	return tb
 }
 
-// shutdownFunction returns the shutdown function.
+// registerHealthCheck reports why tracing is disabled, or the outcome of the last export.
+// Nothing is registered when no exporter is configured.
+func (tb *TracerBuilder) registerHealthCheck(checks *health.Registry) *TracerBuilder {
+	if checks == nil {
+		return tb
+	}
+	switch {
+	case tb.existError():
+		err := tb.err
+		checks.Register(health.Check{Name: TracerCheckName, Probe: func(context.Context) error { return err }})
+	case tb.exporter != nil:
+		checks.Register(health.Check{Name: TracerCheckName, Probe: tb.exporter.check})
+	}
+	return tb
+}
+
+// shutdownFunction returns the shutdown function, or logs why tracing is disabled and
+// returns a no-op.
 func (tb *TracerBuilder) shutdownFunction() func() {
+	if tb.existError() {
+		logger.Error(tb.ctx, "Tracing disabled", logger.ErrorField(logger.FieldError, tb.err))
+		return func() {}
+	}
 	return func() {
-		if err := tb.tp.Shutdown(tb.ctx); err != nil {
-			log.Printf("error shutting down tracer provider: %v", err)
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(tb.ctx), tracerShutdownTimeout)
+		defer cancel()
+		if err := tb.tp.Shutdown(shutdownCtx); err != nil {
+			logger.Error(tb.ctx, "Error shutting down tracer provider", logger.ErrorField(logger.FieldError, err))
 		}
 	}
 }
 
+// newSpanExporter creates the exporter selected by cfg; it returns nil for TraceExporterNone.
+func newSpanExporter(ctx context.Context, cfg config.TracingConfig, endpoint string) (sdktrace.SpanExporter, error) {
+	return newExporter[sdktrace.SpanExporter](cfg, &spanExporters{ctx: ctx, cfg: cfg, endpoint: endpoint})
+}
+
+// spanExporters creates the span exporter of each kind.
+type spanExporters exporterTarget
+
+func (t *spanExporters) otlpGRPC() (sdktrace.SpanExporter, error) {
+	return otlptracegrpc.New(t.ctx, otlpOptions(t.cfg, t.endpoint,
+		otlptracegrpc.WithEndpoint, otlptracegrpc.WithHeaders, otlptracegrpc.WithInsecure)...)
+}
+
+func (t *spanExporters) otlpHTTP() (sdktrace.SpanExporter, error) {
+	return otlptracehttp.New(t.ctx, otlpOptions(t.cfg, t.endpoint,
+		otlptracehttp.WithEndpoint, otlptracehttp.WithHeaders, otlptracehttp.WithInsecure)...)
+}
+
+func (*spanExporters) stdout() (sdktrace.SpanExporter, error) {
+	return stdouttrace.New()
+}
+
+// sampler maps the profile's tracing settings to an SDK sampler.
+func sampler(cfg config.TracingConfig) sdktrace.Sampler {
+	switch cfg.Sampler {
+	case config.SamplerRatio:
+		return sdktrace.TraceIDRatioBased(cfg.SampleRatio)
+	case config.SamplerParentRatio:
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))
+	case config.SamplerNever:
+		return sdktrace.NeverSample()
+	default:
+		return sdktrace.AlwaysSample()
+	}
+}
+
 func createResource(ctx context.Context, cfg config.Provider) (*resource.Resource, error) {
 	return resource.New(ctx, resource.WithAttributes(
 		semconv.ServiceName(cfg.GetAppName()),
 		semconv.DeploymentEnvironment(cfg.GetEnv()),
-		semconv.ServiceVersion("1.0.0"),
+		semconv.ServiceVersion(serviceVersion()),
 	))
 }
 
+// serviceVersion returns the module version the binary was built from, the VCS revision
+// for builds from a checkout, or unknownVersion.
+func serviceVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return unknownVersion
+	}
+	if info.Main.Version != "" && info.Main.Version != develVersion {
+		return info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" && setting.Value != "" {
+			return setting.Value[:min(len(setting.Value), revisionLength)]
+		}
+	}
+	return unknownVersion
+}
+
 func (tb *TracerBuilder) existError() bool {
 	return tb.err != nil
 }
+
+// monitoredExporter remembers the error of the last export.
+type monitoredExporter struct {
+	sdktrace.SpanExporter
+	mu      sync.Mutex
+	lastErr error
+}
+
+func (e *monitoredExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	e.mu.Lock()
+	e.lastErr = err
+	e.mu.Unlock()
+	return err
+}
+
+func (e *monitoredExporter) check(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.lastErr != nil {
+		return fmt.Errorf("last span export failed: %w", e.lastErr)
+	}
+	return nil
+}
+
+const (
+	tracerShutdownTimeout = 5 * time.Second
+	develVersion          = "(devel)"
+	unknownVersion        = "unknown"
+	revisionLength        = 12
+	TracerCheckName       = "tracer"
+)
+
+var ErrUnknownExporter = errors.New("unknown trace exporter")
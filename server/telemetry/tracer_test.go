@@ -0,0 +1,124 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go-service-template/internal/infrastructure/config"
+	"go-service-template/internal/infrastructure/health"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSampler_ParentRatio_FollowsParentDecision(t *testing.T) {
+	s := sampler(config.TracingConfig{Sampler: config.SamplerParentRatio, SampleRatio: 0})
+	traceID := trace.TraceID{1}
+	sampledParent := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: trace.SpanID{1}, TraceFlags: trace.FlagsSampled, Remote: true,
+	}))
+
+	child := s.ShouldSample(sdktrace.SamplingParameters{ParentContext: sampledParent, TraceID: traceID})
+	root := s.ShouldSample(sdktrace.SamplingParameters{ParentContext: context.Background(), TraceID: traceID})
+
+	assert.Equal(t, sdktrace.RecordAndSample, child.Decision)
+	assert.Equal(t, sdktrace.Drop, root.Decision)
+}
+
+func TestNewSpanExporter_SelectsExporter(t *testing.T) {
+	tests := []struct {
+		exporter string
+		wantNil  bool
+	}{
+		{exporter: config.TraceExporterOTLPGRPC},
+		{exporter: config.TraceExporterOTLPHTTP},
+		{exporter: config.TraceExporterStdout},
+		{exporter: config.TraceExporterNone, wantNil: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.exporter, func(t *testing.T) {
+			cfg := config.TracingConfig{Exporter: tt.exporter, TLS: true, Headers: map[string]string{"api-key": "k"}}
+			exporter, err := newSpanExporter(context.Background(), cfg, "localhost:4317")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNil, exporter == nil)
+			if exporter != nil {
+				assert.NoError(t, exporter.Shutdown(context.Background()))
+			}
+		})
+	}
+}
+
+func TestNewSpanExporter_UnknownExporter(t *testing.T) {
+	_, err := newSpanExporter(context.Background(), config.TracingConfig{Exporter: "zipkin"}, "")
+
+	assert.ErrorIs(t, err, ErrUnknownExporter)
+}
+
+// restoreGlobals puts back the tracer provider and propagator that InitTracer replaces.
+func restoreGlobals(t *testing.T) {
+	t.Helper()
+	provider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+}
+
+func TestInitTracer_InvalidExporter_DegradesToNoop(t *testing.T) {
+	restoreGlobals(t)
+	cfg := config.NewConfig()
+	cfg.Tracing.Exporter = "zipkin"
+
+	shutdown := InitTracer(context.Background(), cfg, nil)
+
+	assert.NotPanics(t, shutdown)
+}
+
+func TestInitTracer_NoExporter_StillCreatesSpans(t *testing.T) {
+	restoreGlobals(t)
+	cfg := config.NewConfig()
+	cfg.Tracing.Exporter = config.TraceExporterNone
+
+	shutdown := InitTracer(context.Background(), cfg, nil)
+	t.Cleanup(shutdown)
+	_, span := otel.Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+
+	assert.True(t, span.SpanContext().IsValid())
+}
+
+func TestServiceVersion_NotEmpty(t *testing.T) {
+	assert.NotEmpty(t, serviceVersion())
+}
+
+func TestInitTracer_InvalidExporter_RegistersFailingCheck(t *testing.T) {
+	restoreGlobals(t)
+	cfg := config.NewConfig()
+	cfg.Tracing.Exporter = "zipkin"
+	checks := health.NewRegistry(health.Config{})
+
+	InitTracer(context.Background(), cfg, checks)()
+	report := checks.Report(context.Background())
+
+	assert.Equal(t, health.StatusDegraded, report.Status)
+	assert.Contains(t, report.Checks[TracerCheckName].Error, ErrUnknownExporter.Error())
+}
+
+func TestMonitoredExporter_Check_ReportsLastExport(t *testing.T) {
+	exporter := &monitoredExporter{SpanExporter: failingExporter{}}
+
+	require.NoError(t, exporter.check(context.Background()))
+	_ = exporter.ExportSpans(context.Background(), nil)
+
+	assert.ErrorIs(t, exporter.check(context.Background()), assert.AnError)
+}
+
+type failingExporter struct{ sdktrace.SpanExporter }
+
+func (failingExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	return assert.AnError
+}